@@ -1,11 +1,18 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 
 	"github.com/gr1m0h/sizely/internal/cli"
+	"github.com/gr1m0h/sizely/internal/config"
+	"github.com/gr1m0h/sizely/internal/input"
+	"github.com/gr1m0h/sizely/pkg/sizely"
 )
 
 func main() {
@@ -16,10 +23,22 @@ func main() {
 		pointsCmdWithArgs(os.Args[2:])
 	case "tasks":
 		tasksCmd()
+	case "plan":
+		planCmd(os.Args[2:])
+	case "forecast":
+		forecastCmd(os.Args[2:])
+	case "velocity":
+		velocityCmd(os.Args[2:])
+	case "config":
+		configCmd(os.Args[2:])
+	case "serve":
+		serveCmd(os.Args[2:])
+	case "mcp":
+		mcpCmd(os.Args[2:])
 	case "help", "-help", "--help":
 		cli.ShowHelp()
 	default:
-		fmt.Printf("Unknown command: %s\n", subcommand)
+		fmt.Fprintf(os.Stderr, "Unknown command: %s\n", subcommand)
 		cli.ShowHelp()
 		os.Exit(1)
 	}
@@ -31,26 +50,91 @@ func pointsCmdWithArgs(args []string) {
 	fs.StringVar(inputFile, "f", "", "T-shirt size data from file")
 	inputData := fs.String("data", "", "T-shirt size data from string ")
 	fs.StringVar(inputData, "d", "", "T-shirt size data from string")
+	inputFormat := fs.String("input-format", "auto", "Input format: auto, json or yaml")
+	teamFile := fs.String("team", "", "Team file to compare the plan with the team's capacity")
+	sprintStart := fs.String("sprint-start", "", "First day of the sprint (YYYY-MM-DD)")
+	sprintEnd := fs.String("sprint-end", "", "Last day of the sprint (YYYY-MM-DD)")
+	calendarFile := fs.String("calendar", "", "Holidays and PTO calendar (.ics or YAML)")
+	forecast := fs.Bool("forecast", false, "Simulate the chance of completing the plan from the velocity history")
+	trials := fs.Int("trials", sizely.DefaultTrials, "Number of simulated sprints for --forecast")
+	seed := fs.Int64("seed", 0, "Random seed for --forecast (0 picks one)")
+	fs.String("history", "", "Velocity history file (JSON lines)")
+	fs.String("format", "", "Output format: text, json, yaml, csv, markdown or html")
+	addStyleFlags(fs)
+	templateSpec := fs.String("template", "", "Go template file or inline template text for the output")
+	fs.String("scale", "", "Built-in scale preset (tshirt, fibonacci, pow2, linear)")
+	fs.String("scale-file", "", "Size scale definition file (YAML or JSON)")
 
 	if err := fs.Parse(args); err != nil {
-		fmt.Printf("Error: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	app := cli.NewApp()
+	format, err := input.ParseFormat(*inputFormat)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	app := newApp(loadConfig(fs))
+	useTemplate(app, *templateSpec)
+
+	if *teamFile != "" {
+		if err := app.LoadTeam(*teamFile); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if *sprintStart != "" || *sprintEnd != "" || *calendarFile != "" {
+		if *sprintStart == "" || *sprintEnd == "" {
+			fmt.Fprintln(os.Stderr, "Error: --sprint-start and --sprint-end must be given together")
+			os.Exit(1)
+		}
+		if err := app.SetSprintCalendar(*sprintStart, *sprintEnd, *calendarFile); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
 
-	if *inputFile != "" {
-		if err := app.CalculateFromFile(*inputFile); err != nil {
-			fmt.Printf("Error: %v\n", err)
+	if *forecast {
+		if err := app.EnableForecast(*trials, *seed); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	var piped []byte
+	if *inputFile == "" && *inputData == "" {
+		var err error
+		if piped, _, err = input.ReadPiped(os.Stdin); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if *inputFile == input.StdinName {
+		if err := app.CalculateFromReader(os.Stdin, format); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	} else if *inputFile != "" {
+		if err := app.CalculateFromFile(*inputFile, format); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
 	} else if *inputData != "" {
-		if err := app.CalculateFromJSON(*inputData); err != nil {
-			fmt.Printf("Error: %v\n", err)
+		if err := app.CalculateFromData([]byte(*inputData), format); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	} else if piped != nil {
+		if err := app.CalculateFromData(piped, format); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
 	} else {
-		fmt.Println("Error: points requires either -f/--file or -d/--data")
+		fmt.Fprintln(os.Stderr, "Error: points requires -f/--file, -d/--data or piped standard input")
 		fs.Usage()
 		os.Exit(1)
 	}
@@ -60,19 +144,19 @@ func tasksCmd() {
 	args := os.Args[2:]
 
 	if len(args) < 1 {
-		fmt.Println("Error: tasks requires points as first argument")
-		fmt.Println("Usage: sizely tasks <points> [-c/--count <tasks>] [-o/--output-json]")
+		fmt.Fprintln(os.Stderr, "Error: tasks requires points as first argument")
+		fmt.Fprintln(os.Stderr, "Usage: sizely tasks <points> [-c/--count <tasks>] [--min/--max SIZE=N] [--exclude SIZE] [-o/--output-json]")
 		os.Exit(1)
 	}
 
 	var points int
 	if _, err := fmt.Sscanf(args[0], "%d", &points); err != nil {
-		fmt.Printf("Error: invalid points value '%s', must be a positive integer\n", args[0])
+		fmt.Fprintf(os.Stderr, "Error: invalid points value '%s', must be a positive integer\n", args[0])
 		os.Exit(1)
 	}
 
 	if points <= 0 {
-		fmt.Println("Error: points must be positive")
+		fmt.Fprintln(os.Stderr, "Error: points must be positive")
 		os.Exit(1)
 	}
 
@@ -81,16 +165,461 @@ func tasksCmd() {
 	fs.IntVar(count, "c", 15, "Maximum total tasks count")
 	outputJSON := fs.Bool("output-json", false, "Output results in JSON format")
 	fs.BoolVar(outputJSON, "o", false, "Output results in JSON format")
+	fs.String("format", "", "Output format: text, json, yaml, csv, markdown or html")
+	addStyleFlags(fs)
+	templateSpec := fs.String("template", "", "Go template file or inline template text for the output")
+	fs.String("scale", "", "Built-in scale preset (tshirt, fibonacci, pow2, linear)")
+	fs.String("scale-file", "", "Size scale definition file (YAML or JSON)")
+	var minimums, maximums, excluded listFlag
+	fs.Var(&minimums, "min", "Minimum tasks of a size as SIZE=N (repeatable)")
+	fs.Var(&maximums, "max", "Maximum tasks of a size as SIZE=N (repeatable)")
+	fs.Var(&excluded, "exclude", "Size to leave out of the combinations (repeatable)")
+
+	if err := fs.Parse(args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	cfg := loadConfig(fs)
+	app := newApp(cfg)
+	useTemplate(app, *templateSpec)
+
+	constraints, err := cli.ParseConstraints(minimums, maximums, excluded)
+	if err == nil {
+		err = app.Constrain(constraints)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := app.ReverseCalculate(points); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func planCmd(args []string) {
+	if len(args) < 1 {
+		fmt.Fprintln(os.Stderr, "Error: plan requires points as first argument")
+		fmt.Fprintln(os.Stderr, "Usage: sizely plan <points> -f/--file <backlog> [-c/--count <tasks>]")
+		os.Exit(1)
+	}
+
+	var points int
+	if _, err := fmt.Sscanf(args[0], "%d", &points); err != nil || points <= 0 {
+		fmt.Fprintf(os.Stderr, "Error: invalid points value '%s', must be a positive integer\n", args[0])
+		os.Exit(1)
+	}
+
+	fs := flag.NewFlagSet("plan", flag.ExitOnError)
+	inputFile := fs.String("file", "", "Prioritized backlog file")
+	fs.StringVar(inputFile, "f", "", "Prioritized backlog file")
+	count := fs.Int("count", 15, "Maximum number of tasks to select")
+	fs.IntVar(count, "c", 15, "Maximum number of tasks to select")
+	inputFormat := fs.String("input-format", "auto", "Input format: auto, json or yaml")
+	fs.String("format", "", "Output format: text, json, yaml, csv, markdown or html")
+	addStyleFlags(fs)
+	fs.String("scale", "", "Built-in scale preset (tshirt, fibonacci, pow2, linear)")
+	fs.String("scale-file", "", "Size scale definition file (YAML or JSON)")
+
+	if err := fs.Parse(args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	format, err := input.ParseFormat(*inputFormat)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	var data []byte
+	if *inputFile == "" {
+		var piped bool
+		data, piped, err = input.ReadPiped(os.Stdin)
+		if err == nil && !piped {
+			fmt.Fprintln(os.Stderr, "Error: plan requires -f/--file or piped standard input")
+			fs.Usage()
+			os.Exit(1)
+		}
+	} else {
+		data, format, err = input.ReadFile(*inputFile, format)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	cfg := loadConfig(fs)
+	app := newApp(cfg)
+
+	if err := app.PlanBacklog(data, format, points); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func forecastCmd(args []string) {
+	fs := flag.NewFlagSet("forecast", flag.ExitOnError)
+	inputFile := fs.String("file", "", "Backlog task counts or itemized backlog file")
+	fs.StringVar(inputFile, "f", "", "Backlog task counts or itemized backlog file")
+	inputData := fs.String("data", "", "Backlog task counts or itemized backlog string")
+	fs.StringVar(inputData, "d", "", "Backlog task counts or itemized backlog string")
+	inputFormat := fs.String("input-format", "auto", "Input format: auto, json or yaml")
+	start := fs.String("start", "", "First day of the first forecast sprint (YYYY-MM-DD)")
+	sprintLength := fs.Int("sprint-length", 14, "Sprint length in calendar days")
+	trials := fs.Int("trials", sizely.DefaultTrials, "Number of simulated releases")
+	seed := fs.Int64("seed", 0, "Random seed (0 picks one)")
+	fs.Bool("output-json", false, "Output results in JSON format")
+	fs.Bool("o", false, "Output results in JSON format")
+	fs.String("format", "", "Output format: text, json, yaml, csv, markdown or html")
+	addStyleFlags(fs)
+	fs.String("history", "", "Velocity history file (JSON lines)")
+	fs.String("scale", "", "Built-in scale preset (tshirt, fibonacci, pow2, linear)")
+	fs.String("scale-file", "", "Size scale definition file (YAML or JSON)")
+
+	if err := fs.Parse(args); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	format, err := input.ParseFormat(*inputFormat)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	var data []byte
+	switch {
+	case *inputFile != "":
+		data, format, err = input.ReadFile(*inputFile, format)
+	case *inputData != "":
+		data = []byte(*inputData)
+	default:
+		var piped bool
+		data, piped, err = input.ReadPiped(os.Stdin)
+		if err == nil && !piped {
+			fmt.Fprintln(os.Stderr, "Error: forecast requires -f/--file, -d/--data or piped standard input")
+			fs.Usage()
+			os.Exit(1)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	cfg := loadConfig(fs)
+	app := newApp(cfg)
+
+	opts := cli.ReleaseOptions{
+		Start:        *start,
+		SprintLength: *sprintLength,
+		Trials:       *trials,
+		Seed:         *seed,
+	}
+	if err := app.ForecastRelease(data, format, opts); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func velocityCmd(args []string) {
+	if len(args) > 0 && args[0] == "add" {
+		velocityAddCmd(args[1:])
+		return
+	}
+
+	fs := flag.NewFlagSet("velocity", flag.ExitOnError)
+	window := fs.Int("window", sizely.DefaultVelocityWindow, "Number of recent sprints for the rolling average")
+	fs.IntVar(window, "w", sizely.DefaultVelocityWindow, "Number of recent sprints for the rolling average")
+	fs.String("history", "", "Velocity history file (JSON lines)")
+	fs.String("format", "", "Output format: text, json, yaml, csv, markdown or html")
+	addStyleFlags(fs)
+	fs.String("scale", "", "Built-in scale preset (tshirt, fibonacci, pow2, linear)")
+	fs.String("scale-file", "", "Size scale definition file (YAML or JSON)")
+
+	if err := fs.Parse(args); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	app := newApp(loadConfig(fs))
+
+	if err := app.ShowVelocity(*window); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func velocityAddCmd(args []string) {
+	fs := flag.NewFlagSet("velocity add", flag.ExitOnError)
+	sprint := fs.String("sprint", "", "Sprint name, e.g. 42 or 2026-W41")
+	planned := fs.String("planned", "", "Planned task counts or backlog (JSON or YAML string)")
+	completed := fs.String("completed", "", "Completed task counts or backlog (JSON or YAML string)")
+	plannedFile := fs.String("planned-file", "", "File with the planned task counts or backlog")
+	completedFile := fs.String("completed-file", "", "File with the completed task counts or backlog")
+	start := fs.String("start", "", "First day of the sprint (YYYY-MM-DD)")
+	end := fs.String("end", "", "Last day of the sprint (YYYY-MM-DD)")
+	fs.String("history", "", "Velocity history file (JSON lines)")
+	fs.String("scale", "", "Built-in scale preset (tshirt, fibonacci, pow2, linear)")
+	fs.String("scale-file", "", "Size scale definition file (YAML or JSON)")
+
+	if err := fs.Parse(args); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *sprint == "" {
+		fmt.Fprintln(os.Stderr, "Error: velocity add requires --sprint")
+		fmt.Fprintln(os.Stderr, "Usage: sizely velocity add --sprint <name> --planned <counts> --completed <counts>")
+		os.Exit(1)
+	}
+
+	plannedData := readDocument("planned", *planned, *plannedFile)
+	completedData := readDocument("completed", *completed, *completedFile)
+
+	app := newApp(loadConfig(fs))
+
+	if err := app.RecordSprint(*sprint, *start, *end, plannedData, completedData); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// readDocument returns the document given inline with --name or as a file with --name-file
+func readDocument(name, data, filename string) []byte {
+	switch {
+	case data != "" && filename != "":
+		fmt.Fprintf(os.Stderr, "Error: use either --%s or --%s-file, not both\n", name, name)
+		os.Exit(1)
+	case filename != "":
+		content, _, err := input.ReadFile(filename, input.FormatAuto)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return content
+	case data == "":
+		fmt.Fprintf(os.Stderr, "Error: velocity add requires --%s or --%s-file\n", name, name)
+		os.Exit(1)
+	}
+
+	return []byte(data)
+}
+
+func configCmd(args []string) {
+	if len(args) < 1 || args[0] != "show" {
+		fmt.Fprintln(os.Stderr, "Error: config requires a subcommand")
+		fmt.Fprintln(os.Stderr, "Usage: sizely config show")
+		os.Exit(1)
+	}
+
+	fs := flag.NewFlagSet("config show", flag.ExitOnError)
+	fs.String("format", "", "Output format: text, json, yaml, csv, markdown or html")
+	addStyleFlags(fs)
+	fs.String("scale", "", "Built-in scale preset (tshirt, fibonacci, pow2, linear)")
+	fs.String("scale-file", "", "Size scale definition file (YAML or JSON)")
 
 	if err := fs.Parse(args[1:]); err != nil {
-		fmt.Printf("Error: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := newApp(loadConfig(fs)).ShowConfig(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func serveCmd(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", "localhost:8080", "Address to listen on (localhost:50051 with --grpc)")
+	grpc := fs.Bool("grpc", false, "Serve the gRPC service instead of the HTTP API")
+	fs.Int("count", 15, "Default maximum total tasks for combinations")
+	fs.Int("c", 15, "Default maximum total tasks for combinations")
+	fs.String("scale", "", "Built-in scale preset (tshirt, fibonacci, pow2, linear)")
+	fs.String("scale-file", "", "Size scale definition file (YAML or JSON)")
+
+	if err := fs.Parse(args); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	app := newApp(loadConfig(fs))
+
+	var err error
+	if *grpc {
+		if !isFlagSet(fs, "addr") {
+			*addr = "localhost:50051"
+		}
+		err = app.ServeGRPC(ctx, *addr)
+	} else {
+		err = app.Serve(ctx, *addr)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func mcpCmd(args []string) {
+	fs := flag.NewFlagSet("mcp", flag.ExitOnError)
+	fs.Int("count", 15, "Default maximum total tasks of the tools")
+	fs.Int("c", 15, "Default maximum total tasks of the tools")
+	fs.String("scale", "", "Built-in scale preset (tshirt, fibonacci, pow2, linear)")
+	fs.String("scale-file", "", "Size scale definition file (YAML or JSON)")
+
+	if err := fs.Parse(args); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	app := cli.NewApp()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	if err := app.ReverseCalculate(points, *count, *outputJSON); err != nil {
-		fmt.Printf("Error: %v\n", err)
+	if err := newApp(loadConfig(fs)).ServeMCP(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
+
+// addStyleFlags adds the flags controlling colors, symbols and width of the text output
+func addStyleFlags(fs *flag.FlagSet) {
+	fs.String("color", "", "Colorize text output: auto, always or never")
+	fs.Bool("no-color", false, "Same as --color never")
+	fs.Bool("ascii", false, "Replace emoji and box-drawing characters with plain ASCII")
+	fs.Int("width", 0, "Width of the text output in columns (0 uses the terminal width)")
+}
+
+// flagKeys maps command-line flags to the configuration keys they override
+var flagKeys = map[string]string{
+	"scale":       config.KeyScale,
+	"scale-file":  config.KeyScaleFile,
+	"count":       config.KeyMaxTasks,
+	"c":           config.KeyMaxTasks,
+	"output-json": config.KeyFormat,
+	"o":           config.KeyFormat,
+	"format":      config.KeyFormat,
+	"color":       config.KeyColor,
+	"no-color":    config.KeyColor,
+	"ascii":       config.KeyASCII,
+	"width":       config.KeyWidth,
+	"history":     config.KeyHistoryFile,
+}
+
+// loadConfig loads the layered configuration for the working directory and applies the flags set on fs
+func loadConfig(fs *flag.FlagSet) *config.Config {
+	wd, err := os.Getwd()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	cfg, err := config.Load(wd)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if isFlagSet(fs, "scale") && isFlagSet(fs, "scale-file") {
+		fmt.Fprintln(os.Stderr, "Error: use either --scale or --scale-file, not both")
+		os.Exit(1)
+	}
+
+	fs.Visit(func(f *flag.Flag) {
+		key, ok := flagKeys[f.Name]
+		if !ok || err != nil {
+			return
+		}
+
+		value := f.Value.String()
+		switch f.Name {
+		case "output-json", "o":
+			// -o/--output-json is a boolean shorthand for the json format
+			if value != "true" {
+				return
+			}
+			value = "json"
+		case "no-color":
+			if value != "true" {
+				return
+			}
+			value = config.ColorNever
+		}
+
+		err = cfg.Set(key, value, "flag "+flagName(f.Name))
+	})
+
+	if err == nil {
+		err = cfg.Validate()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	return cfg
+}
+
+// newApp creates the application for cfg, exiting when the output format is unavailable
+func newApp(cfg *config.Config) *cli.App {
+	app, err := cli.NewApp(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	return app
+}
+
+// useTemplate switches app to the --template output when one was given
+func useTemplate(app *cli.App, spec string) {
+	if spec == "" {
+		return
+	}
+
+	if err := app.UseTemplate(spec); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// listFlag collects the values of a flag given more than once
+type listFlag []string
+
+// String returns the values joined by commas
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+// Set adds a value
+func (l *listFlag) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// flagName returns how a flag is spelled on the command line, e.g. -c or --count
+func flagName(name string) string {
+	if len(name) == 1 {
+		return "-" + name
+	}
+
+	return "--" + name
+}
+
+// isFlagSet reports whether the named flag was given on the command line
+func isFlagSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+
+	return set
+}
@@ -1,123 +1,326 @@
 package calculator
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
 	"sort"
 
 	"github.com/gr1m0h/sizely/internal/models"
+	"github.com/gr1m0h/sizely/internal/scale"
 )
 
 // Calculator handles sprint capacity calculations
-type Calculator struct{}
+type Calculator struct {
+	scale scale.Scale
+}
 
-// NewCalculator creates a new Calculator instance
+// NewCalculator creates a new Calculator instance using the default T-shirt scale
 func NewCalculator() *Calculator {
-	return &Calculator{}
+	return NewCalculatorWithScale(scale.Default())
+}
+
+// NewCalculatorWithScale creates a new Calculator instance for the given scale
+func NewCalculatorWithScale(s scale.Scale) *Calculator {
+	return &Calculator{scale: s}
+}
+
+// Scale returns the size scale used by the calculator
+func (c *Calculator) Scale() scale.Scale {
+	return c.scale
+}
+
+// ValidateTasks checks that every size in tasks exists in the scale and has a non-negative count
+func (c *Calculator) ValidateTasks(tasks models.TaskCount) error {
+	for name, count := range tasks {
+		if _, ok := c.scale.Lookup(name); !ok {
+			return fmt.Errorf("unknown size %q (expected one of %v)", name, c.scale.Names())
+		}
+		if count < 0 {
+			return fmt.Errorf("size %s: count must not be negative", name)
+		}
+	}
+
+	return nil
+}
+
+// NormalizeTasks returns the task counts keyed by the scale's size names, with every size present
+func (c *Calculator) NormalizeTasks(tasks models.TaskCount) models.TaskCount {
+	normalized := make(models.TaskCount, len(c.scale.Sizes))
+	for _, size := range c.scale.Sizes {
+		normalized[size.Name] = 0
+	}
+
+	for name, count := range tasks {
+		if size, ok := c.scale.Lookup(name); ok {
+			normalized[size.Name] += count
+		}
+	}
+
+	return normalized
 }
 
 // CalculatePoints calculates total points from task counts
 func (c *Calculator) CalculatePoints(tasks models.TaskCount) int {
-	return tasks.XS*models.TShirtSizePoints["XS"] +
-		tasks.S*models.TShirtSizePoints["S"] +
-		tasks.M*models.TShirtSizePoints["M"] +
-		tasks.L*models.TShirtSizePoints["L"]
+	total := 0
+	for name, count := range tasks {
+		if size, ok := c.scale.Lookup(name); ok {
+			total += count * size.Points
+		}
+	}
+
+	return total
 }
 
 // CalculateSprintCapacity calculates complete sprint capacity with assessment
 func (c *Calculator) CalculateSprintCapacity(tasks models.TaskCount) models.SprintCapacity {
+	tasks = c.NormalizeTasks(tasks)
+
 	// Create breakdown
-	breakdown := []models.TaskBreakdown{
-		{
-			Size:   "XS",
-			Count:  tasks.XS,
-			Points: models.TShirtSizePoints["XS"],
-			Total:  tasks.XS * models.TShirtSizePoints["XS"],
-		},
-		{
-			Size:   "S",
-			Count:  tasks.S,
-			Points: models.TShirtSizePoints["S"],
-			Total:  tasks.S * models.TShirtSizePoints["S"],
-		},
-		{
-			Size:   "M",
-			Count:  tasks.M,
-			Points: models.TShirtSizePoints["M"],
-			Total:  tasks.M * models.TShirtSizePoints["M"],
-		},
-		{
-			Size:   "L",
-			Count:  tasks.L,
-			Points: models.TShirtSizePoints["L"],
-			Total:  tasks.L * models.TShirtSizePoints["L"],
-		},
+	breakdown := make([]models.TaskBreakdown, 0, len(c.scale.Sizes))
+	for _, size := range c.scale.Sizes {
+		breakdown = append(breakdown, models.TaskBreakdown{
+			Size:   size.Name,
+			Count:  tasks[size.Name],
+			Points: size.Points,
+			Total:  tasks[size.Name] * size.Points,
+		})
 	}
 
 	totalPoints := c.CalculatePoints(tasks)
-	totalTasks := tasks.XS + tasks.S + tasks.M + tasks.L
+	totalTasks := tasks.Total()
 
 	return models.SprintCapacity{
 		TotalPoints: totalPoints,
 		TotalTasks:  totalTasks,
 		Breakdown:   breakdown,
 		Tasks:       tasks,
+		Effort:      c.EstimateEffort(tasks),
+	}
+}
+
+// EstimateEffort sums the PERT estimates of the hour ranges per size; the standard deviations
+// combine as independent tasks. It returns nil when the scale defines no hours.
+func (c *Calculator) EstimateEffort(tasks models.TaskCount) *models.Effort {
+	if !c.scale.HasHours() {
+		return nil
+	}
+
+	tasks = c.NormalizeTasks(tasks)
+	effort := &models.Effort{}
+	variance := 0.0
+	for _, size := range c.scale.Sizes {
+		count := tasks[size.Name]
+		if count == 0 {
+			continue
+		}
+
+		if size.Hours == nil {
+			effort.Unestimated = append(effort.Unestimated, size.Name)
+			continue
+		}
+
+		n := float64(count)
+		effort.MinHours += n * size.Hours.Min
+		effort.ExpectedHours += n * size.Hours.Expected()
+		effort.MaxHours += n * size.Hours.Max
+		variance += n * size.Hours.StdDev() * size.Hours.StdDev()
+	}
+	effort.StdDevHours = math.Sqrt(variance)
+
+	return effort
+}
+
+// CountBacklog counts backlog items per size, rejecting unknown sizes and duplicate IDs
+func (c *Calculator) CountBacklog(items []models.BacklogItem) (models.TaskCount, error) {
+	tasks := c.NormalizeTasks(nil)
+	seen := make(map[string]bool, len(items))
+
+	for i, item := range items {
+		if item.ID != "" {
+			if seen[item.ID] {
+				return nil, fmt.Errorf("duplicate backlog item ID %q", item.ID)
+			}
+			seen[item.ID] = true
+		}
+
+		size, ok := c.scale.Lookup(item.Size)
+		if !ok {
+			return nil, fmt.Errorf("backlog item %s: unknown size %q (expected one of %v)", itemLabel(i, item), item.Size, c.scale.Names())
+		}
+		tasks[size.Name]++
+	}
+
+	return tasks, nil
+}
+
+// CalculateBacklogCapacity calculates sprint capacity from itemized backlog tasks,
+// listing the items of each size in the breakdown
+func (c *Calculator) CalculateBacklogCapacity(items []models.BacklogItem) (models.SprintCapacity, error) {
+	tasks, err := c.CountBacklog(items)
+	if err != nil {
+		return models.SprintCapacity{}, err
+	}
+
+	capacity := c.CalculateSprintCapacity(tasks)
+	for i := range capacity.Breakdown {
+		for _, item := range items {
+			if size, _ := c.scale.Lookup(item.Size); size.Name == capacity.Breakdown[i].Size {
+				capacity.Breakdown[i].Items = append(capacity.Breakdown[i].Items, item)
+			}
+		}
 	}
+
+	return capacity, nil
 }
 
 // FindCombinations finds all task combinations for target points
 func (c *Calculator) FindCombinations(targetPoints, maxTasks int) models.CombinationResult {
-	combinations := c.generateCombinations(targetPoints, maxTasks)
+	result, _ := c.FindCombinationsContext(context.Background(), targetPoints, maxTasks, models.Constraints{})
+
+	return result
+}
+
+// FindCombinationsContext finds all task combinations for target points that also respect the
+// per-size constraints, stopping the search with the context's error once ctx is done
+func (c *Calculator) FindCombinationsContext(ctx context.Context, targetPoints, maxTasks int, constraints models.Constraints) (models.CombinationResult, error) {
+	return c.FindCombinationsLimit(ctx, targetPoints, maxTasks, constraints, 0)
+}
+
+// FindCombinationsLimit is FindCombinationsContext failing with an error wrapping
+// ErrTooManyCombinations once more than limit combinations are found; a limit of 0 finds all
+func (c *Calculator) FindCombinationsLimit(ctx context.Context, targetPoints, maxTasks int, constraints models.Constraints, limit int) (models.CombinationResult, error) {
+	constraints, err := c.NormalizeConstraints(constraints)
+	if err != nil {
+		return models.CombinationResult{}, err
+	}
+
+	combinations := []models.Combination{}
+	err = c.generateCombinations(ctx, targetPoints, maxTasks, constraints, func(combo models.Combination) error {
+		if limit > 0 && len(combinations) == limit {
+			return fmt.Errorf("%w: more than %d found", ErrTooManyCombinations, limit)
+		}
+		combinations = append(combinations, combo)
+		return nil
+	})
+	if err != nil {
+		return models.CombinationResult{}, err
+	}
 
-	return models.CombinationResult{
+	// Sort combinations by total tasks (ascending)
+	sort.SliceStable(combinations, func(i, j int) bool {
+		return combinations[i].TotalTasks() < combinations[j].TotalTasks()
+	})
+
+	result := models.CombinationResult{
 		TargetPoints: targetPoints,
 		MaxTasks:     maxTasks,
 		Combinations: combinations,
 		TotalFound:   len(combinations),
 	}
+	if !constraints.IsZero() {
+		result.Constraints = &constraints
+	}
+
+	return result, nil
 }
 
-// generateCombinations generates all valid combinations for target points
-func (c *Calculator) generateCombinations(targetPoints, maxTasks int) []models.Combination {
-	var combinations []models.Combination
+// EachCombination calls yield with every task combination for target points that respects the
+// per-size constraints, as the search finds them rather than fewest tasks first. It stops with
+// the first error of yield, or with the context's error once ctx is done.
+func (c *Calculator) EachCombination(ctx context.Context, targetPoints, maxTasks int, constraints models.Constraints, yield func(models.Combination) error) error {
+	constraints, err := c.NormalizeConstraints(constraints)
+	if err != nil {
+		return err
+	}
 
-	maxL := min(targetPoints/models.TShirtSizePoints["L"], maxTasks)
+	return c.generateCombinations(ctx, targetPoints, maxTasks, constraints, yield)
+}
+
+// ErrTooManyCombinations is reported when a search finds more combinations than its limit
+var ErrTooManyCombinations = errors.New("too many combinations")
+
+// cancelCheckInterval is how many search steps run between checks for a canceled context
+const cancelCheckInterval = 1 << 12
 
-	for l := 0; l <= maxL; l++ {
-		remainingAfterL := targetPoints - l*models.TShirtSizePoints["L"]
-		maxM := min(remainingAfterL/models.TShirtSizePoints["M"], maxTasks-l)
+// generateCombinations passes every valid combination for target points within the normalized
+// constraints to yield, stopping at its first error
+func (c *Calculator) generateCombinations(ctx context.Context, targetPoints, maxTasks int, constraints models.Constraints, yield func(models.Combination) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 
-		for m := 0; m <= maxM; m++ {
-			remainingAfterM := remainingAfterL - m*models.TShirtSizePoints["M"]
-			maxS := min(remainingAfterM/models.TShirtSizePoints["S"], maxTasks-l-m)
+	// Walk sizes from largest to smallest so the smallest size absorbs the remainder
+	sizes := c.scale.Descending()
+	counts := make([]int, len(sizes))
 
-			for s := 0; s <= maxS; s++ {
-				remainingAfterS := remainingAfterM - s*models.TShirtSizePoints["S"]
+	// lo and hi bound the count of each size; restPoints[i] and restTasks[i] are the points and
+	// tasks the minimums of sizes[i:] take up, so no branch is entered that cannot meet them
+	lo, hi := make([]int, len(sizes)), make([]int, len(sizes))
+	restPoints, restTasks := make([]int, len(sizes)+1), make([]int, len(sizes)+1)
+	for i := len(sizes) - 1; i >= 0; i-- {
+		lo[i], hi[i] = sizeBounds(constraints, sizes[i])
+		// Minimums that cannot fit the points or tasks left by the smaller sizes can never be met;
+		// comparing before summing keeps the sums from overflowing
+		if lo[i] > maxTasks-restTasks[i+1] || lo[i] > (targetPoints-restPoints[i+1])/sizes[i].Points {
+			return nil
+		}
+		restPoints[i] = restPoints[i+1] + lo[i]*sizes[i].Points
+		restTasks[i] = restTasks[i+1] + lo[i]
+	}
 
-				// XS must match exactly and not exceed max tasks
-				if remainingAfterS >= 0 && remainingAfterS%models.TShirtSizePoints["XS"] == 0 {
-					xs := remainingAfterS / models.TShirtSizePoints["XS"]
-					totalTasks := l + m + s + xs
+	steps := 0
+	var err error
+	var search func(i, remaining, tasksLeft int)
+	search = func(i, remaining, tasksLeft int) {
+		if steps++; steps%cancelCheckInterval == 0 && err == nil {
+			err = ctx.Err()
+		}
+		if err != nil || remaining < restPoints[i] || tasksLeft < restTasks[i] {
+			return
+		}
+
+		size := sizes[i]
+
+		// The smallest size must match exactly and not exceed max tasks or its bounds
+		if i == len(sizes)-1 {
+			n := remaining / size.Points
+			if remaining%size.Points != 0 || n > tasksLeft || n < lo[i] || n > hi[i] {
+				return
+			}
+			counts[i] = n
 
-					if totalTasks <= maxTasks {
-						combo := models.Combination{
-							XS:     xs,
-							S:      s,
-							M:      m,
-							L:      l,
-							Points: targetPoints,
-						}
-						combinations = append(combinations, combo)
-					}
-				}
+			combo := models.Combination{
+				Counts: make(models.TaskCount, len(sizes)),
+				Points: targetPoints,
 			}
+			for j, s := range sizes {
+				combo.Counts[s.Name] = counts[j]
+			}
+			err = yield(combo)
+			return
+		}
+
+		maxCount := min(hi[i], (remaining-restPoints[i+1])/size.Points, tasksLeft-restTasks[i+1])
+		for n := lo[i]; n <= maxCount && err == nil; n++ {
+			counts[i] = n
+			search(i+1, remaining-n*size.Points, tasksLeft-n)
 		}
 	}
 
-	// Sort combinations by total tasks (ascending)
-	sort.Slice(combinations, func(i, j int) bool {
-		totalI := combinations[i].XS + combinations[i].S + combinations[i].M + combinations[i].L
-		totalJ := combinations[j].XS + combinations[j].S + combinations[j].M + combinations[j].L
-		return totalI < totalJ
-	})
+	if targetPoints >= 0 && maxTasks >= 0 {
+		search(0, targetPoints, maxTasks)
+	}
+
+	return err
+}
+
+// itemLabel identifies a backlog item in error messages by its ID or its position
+func itemLabel(index int, item models.BacklogItem) string {
+	if item.ID != "" {
+		return item.ID
+	}
 
-	return combinations
+	return fmt.Sprintf("#%d", index+1)
 }
@@ -1,12 +1,34 @@
 package calculator
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
 	"testing"
 
 	"github.com/gr1m0h/sizely/internal/models"
+	"github.com/gr1m0h/sizely/internal/scale"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+func extendedScale(t *testing.T) scale.Scale {
+	t.Helper()
+
+	s, err := scale.New("extended", []scale.Size{
+		{Name: "XS", Points: 1},
+		{Name: "S", Points: 2},
+		{Name: "M", Points: 3},
+		{Name: "L", Points: 5},
+		{Name: "XL", Points: 8},
+		{Name: "XXL", Points: 13},
+	})
+	require.NoError(t, err)
+
+	return s
+}
+
 func TestCalculatePoints(t *testing.T) {
 	calc := NewCalculator()
 
@@ -17,22 +39,22 @@ func TestCalculatePoints(t *testing.T) {
 	}{
 		{
 			name:     "Empty tasks",
-			tasks:    models.TaskCount{XS: 0, S: 0, M: 0, L: 0},
+			tasks:    models.TaskCount{"XS": 0, "S": 0, "M": 0, "L": 0},
 			expected: 0,
 		},
 		{
 			name:     "Only XS tasks",
-			tasks:    models.TaskCount{XS: 5, S: 0, M: 0, L: 0},
+			tasks:    models.TaskCount{"XS": 5, "S": 0, "M": 0, "L": 0},
 			expected: 5,
 		},
 		{
 			name:     "Mixed tasks",
-			tasks:    models.TaskCount{XS: 3, S: 2, M: 1, L: 1},
+			tasks:    models.TaskCount{"XS": 3, "S": 2, "M": 1, "L": 1},
 			expected: 24, // 3*1 + 2*3 + 1*5 + 1*10 = 3 + 6 + 5 + 10 = 24
 		},
 		{
 			name:     "All large tasks",
-			tasks:    models.TaskCount{XS: 0, S: 0, M: 0, L: 3},
+			tasks:    models.TaskCount{"XS": 0, "S": 0, "M": 0, "L": 3},
 			expected: 30,
 		},
 	}
@@ -47,7 +69,7 @@ func TestCalculatePoints(t *testing.T) {
 
 func TestCalculateSprintCapacity(t *testing.T) {
 	calc := NewCalculator()
-	tasks := models.TaskCount{XS: 3, S: 2, M: 1, L: 1}
+	tasks := models.TaskCount{"XS": 3, "S": 2, "M": 1, "L": 1}
 
 	result := calc.CalculateSprintCapacity(tasks)
 
@@ -120,8 +142,8 @@ func TestFindCombinations(t *testing.T) {
 
 			// Verify all combinations are valid
 			for _, combo := range result.Combinations {
-				totalPoints := combo.XS*1 + combo.S*3 + combo.M*5 + combo.L*10
-				totalTasks := combo.XS + combo.S + combo.M + combo.L
+				totalPoints := combo.Counts["XS"]*1 + combo.Counts["S"]*3 + combo.Counts["M"]*5 + combo.Counts["L"]*10
+				totalTasks := combo.Counts["XS"] + combo.Counts["S"] + combo.Counts["M"] + combo.Counts["L"]
 
 				assert.Equal(t, tt.targetPoints, totalPoints, "Combination points should match target")
 				assert.LessOrEqual(t, totalTasks, tt.maxTasks, "Combination tasks should not exceed max")
@@ -137,15 +159,22 @@ func TestGenerateCombinationsEdgeCases(t *testing.T) {
 	t.Run("No valid combinations", func(t *testing.T) {
 		result := calc.FindCombinations(2, 1) // 2 points with max 1 task - XS×2 = 2 tasks, exceeds max
 		assert.Equal(t, 0, result.TotalFound) // No valid combinations possible
+		assert.NotNil(t, result.Combinations) // encoded as [] rather than null
 	})
 
 	t.Run("Zero points", func(t *testing.T) {
 		result := calc.FindCombinations(0, 5)
 		assert.Equal(t, 1, result.TotalFound) // Only empty combination
-		assert.Equal(t, 0, result.Combinations[0].XS)
-		assert.Equal(t, 0, result.Combinations[0].S)
-		assert.Equal(t, 0, result.Combinations[0].M)
-		assert.Equal(t, 0, result.Combinations[0].L)
+		assert.Equal(t, 0, result.Combinations[0].Counts["XS"])
+		assert.Equal(t, 0, result.Combinations[0].Counts["S"])
+		assert.Equal(t, 0, result.Combinations[0].Counts["M"])
+		assert.Equal(t, 0, result.Combinations[0].Counts["L"])
+	})
+
+	t.Run("Largest max tasks", func(t *testing.T) {
+		result := calc.FindCombinations(10, math.MaxInt)
+		assert.Equal(t, calc.FindCombinations(10, 10).Combinations, result.Combinations)
+		assert.Equal(t, math.MaxInt, result.MaxTasks)
 	})
 
 	t.Run("Very restrictive max tasks", func(t *testing.T) {
@@ -153,27 +182,98 @@ func TestGenerateCombinationsEdgeCases(t *testing.T) {
 		assert.Greater(t, result.TotalFound, 0)
 
 		for _, combo := range result.Combinations {
-			totalTasks := combo.XS + combo.S + combo.M + combo.L
+			totalTasks := combo.Counts["XS"] + combo.Counts["S"] + combo.Counts["M"] + combo.Counts["L"]
 			assert.LessOrEqual(t, totalTasks, 3)
 		}
 	})
 }
 
+func TestCustomScale(t *testing.T) {
+	calc := NewCalculatorWithScale(extendedScale(t))
+
+	t.Run("Points use scale values", func(t *testing.T) {
+		tasks := models.TaskCount{"xs": 1, "M": 2, "xl": 1, "XXL": 1}
+		assert.Equal(t, 1+2*3+8+13, calc.CalculatePoints(tasks))
+	})
+
+	t.Run("Capacity breakdown covers every size", func(t *testing.T) {
+		result := calc.CalculateSprintCapacity(models.TaskCount{"xxl": 2})
+
+		assert.Len(t, result.Breakdown, 6)
+		assert.Equal(t, "XXL", result.Breakdown[5].Size)
+		assert.Equal(t, 26, result.Breakdown[5].Total)
+		assert.Equal(t, 26, result.TotalPoints)
+		assert.Equal(t, 2, result.TotalTasks)
+		assert.Equal(t, 0, result.Tasks["XS"])
+	})
+
+	t.Run("Combinations use every size", func(t *testing.T) {
+		result := calc.FindCombinations(21, 2)
+
+		require.NotEmpty(t, result.Combinations)
+		for _, combo := range result.Combinations {
+			assert.Equal(t, 21, calc.CalculatePoints(combo.Counts))
+			assert.LessOrEqual(t, combo.TotalTasks(), 2)
+		}
+		assert.Equal(t, models.TaskCount{"XS": 0, "S": 0, "M": 0, "L": 0, "XL": 1, "XXL": 1}, result.Combinations[0].Counts)
+	})
+}
+
+func TestValidateTasks(t *testing.T) {
+	calc := NewCalculator()
+
+	assert.NoError(t, calc.ValidateTasks(models.TaskCount{"xs": 1, "L": 2}))
+	assert.ErrorContains(t, calc.ValidateTasks(models.TaskCount{"XL": 1}), "unknown size")
+	assert.ErrorContains(t, calc.ValidateTasks(models.TaskCount{"M": -1}), "must not be negative")
+}
+
+func TestCalculateBacklogCapacity(t *testing.T) {
+	calc := NewCalculator()
+	items := []models.BacklogItem{
+		{ID: "API-12", Title: "Rate limiting", Size: "M"},
+		{ID: "WEB-3", Title: "Fix typo", Size: "xs"},
+		{ID: "API-15", Title: "Webhooks", Size: "L"},
+		{ID: "OPS-2", Title: "Rotate credentials", Size: "XS"},
+	}
+
+	result, err := calc.CalculateBacklogCapacity(items)
+	require.NoError(t, err)
+
+	assert.Equal(t, 17, result.TotalPoints)
+	assert.Equal(t, 4, result.TotalTasks)
+	assert.Equal(t, models.TaskCount{"XS": 2, "S": 0, "M": 1, "L": 1}, result.Tasks)
+
+	assert.Equal(t, []models.BacklogItem{items[1], items[3]}, result.Breakdown[0].Items)
+	assert.Empty(t, result.Breakdown[1].Items)
+	assert.Equal(t, []models.BacklogItem{items[0]}, result.Breakdown[2].Items)
+	assert.Equal(t, []models.BacklogItem{items[2]}, result.Breakdown[3].Items)
+
+	t.Run("Unknown size", func(t *testing.T) {
+		_, err := calc.CalculateBacklogCapacity([]models.BacklogItem{{Title: "No ID", Size: "XXL"}})
+		assert.ErrorContains(t, err, `backlog item #1: unknown size "XXL"`)
+	})
+
+	t.Run("Duplicate ID", func(t *testing.T) {
+		_, err := calc.CalculateBacklogCapacity([]models.BacklogItem{{ID: "A", Size: "S"}, {ID: "A", Size: "M"}})
+		assert.ErrorContains(t, err, `duplicate backlog item ID "A"`)
+	})
+}
+
 func TestCombinationsSorting(t *testing.T) {
 	calc := NewCalculator()
 	result := calc.FindCombinations(15, 10)
 
 	// Check that combinations are sorted by total tasks (ascending)
 	for i := 1; i < len(result.Combinations); i++ {
-		prevTotal := result.Combinations[i-1].XS + result.Combinations[i-1].S + result.Combinations[i-1].M + result.Combinations[i-1].L
-		currTotal := result.Combinations[i].XS + result.Combinations[i].S + result.Combinations[i].M + result.Combinations[i].L
+		prevTotal := result.Combinations[i-1].TotalTasks()
+		currTotal := result.Combinations[i].TotalTasks()
 		assert.LessOrEqual(t, prevTotal, currTotal, "Combinations should be sorted by total tasks")
 	}
 }
 
 func BenchmarkCalculatePoints(b *testing.B) {
 	calc := NewCalculator()
-	tasks := models.TaskCount{XS: 5, S: 3, M: 2, L: 1}
+	tasks := models.TaskCount{"XS": 5, "S": 3, "M": 2, "L": 1}
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -189,3 +289,182 @@ func BenchmarkFindCombinations(b *testing.B) {
 		calc.FindCombinations(33, 15)
 	}
 }
+
+func TestEstimateEffort(t *testing.T) {
+	t.Run("Default scale", func(t *testing.T) {
+		effort := NewCalculator().EstimateEffort(models.TaskCount{"xs": 2, "M": 1, "L": 1})
+		require.NotNil(t, effort)
+
+		// XS: (0.5 + 8 + 4) / 6, M: (16 + 80 + 24) / 6 = 20, L: (32 + 160 + 48) / 6 = 40
+		assert.InDelta(t, 2*12.5/6+20+40, effort.ExpectedHours, 1e-9)
+		assert.Equal(t, 2*0.5+16+32, effort.MinHours)
+		assert.Equal(t, 2*4.0+24+48, effort.MaxHours)
+		assert.InDelta(t, math.Sqrt(2*math.Pow(3.5/6, 2)+math.Pow(8.0/6, 2)+math.Pow(16.0/6, 2)), effort.StdDevHours, 1e-9)
+		assert.Empty(t, effort.Unestimated)
+	})
+
+	t.Run("Partial hours", func(t *testing.T) {
+		s, err := scale.New("partial", []scale.Size{
+			{Name: "S", Points: 1, Hours: &scale.Hours{Min: 2, Max: 6}},
+			{Name: "XL", Points: 8},
+		})
+		require.NoError(t, err)
+
+		effort := NewCalculatorWithScale(s).EstimateEffort(models.TaskCount{"S": 3, "XL": 1})
+		require.NotNil(t, effort)
+		assert.Equal(t, 12.0, effort.ExpectedHours)
+		assert.Equal(t, []string{"XL"}, effort.Unestimated)
+	})
+
+	t.Run("No hours", func(t *testing.T) {
+		s, err := scale.Preset("fibonacci")
+		require.NoError(t, err)
+
+		assert.Nil(t, NewCalculatorWithScale(s).EstimateEffort(models.TaskCount{"M": 1}))
+		assert.Nil(t, NewCalculatorWithScale(s).CalculateSprintCapacity(models.TaskCount{"M": 1}).Effort)
+	})
+}
+
+func TestFindCombinationsContext(t *testing.T) {
+	calc := NewCalculator()
+
+	result, err := calc.FindCombinationsContext(context.Background(), 13, 3, models.Constraints{})
+	require.NoError(t, err)
+	assert.Equal(t, calc.FindCombinations(13, 3), result)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = calc.FindCombinationsContext(ctx, 13, 3, models.Constraints{})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestFindCombinationsLimit(t *testing.T) {
+	calc := NewCalculator()
+	all := calc.FindCombinations(13, 15)
+
+	result, err := calc.FindCombinationsLimit(context.Background(), 13, 15, models.Constraints{}, all.TotalFound)
+	require.NoError(t, err)
+	assert.Equal(t, all, result)
+
+	_, err = calc.FindCombinationsLimit(context.Background(), 13, 15, models.Constraints{}, all.TotalFound-1)
+	assert.ErrorIs(t, err, ErrTooManyCombinations)
+	assert.EqualError(t, err, fmt.Sprintf("too many combinations: more than %d found", all.TotalFound-1))
+}
+
+func TestEachCombination(t *testing.T) {
+	calc := NewCalculator()
+
+	var found []models.Combination
+	err := calc.EachCombination(context.Background(), 13, 15, models.Constraints{}, func(combo models.Combination) error {
+		found = append(found, combo)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, calc.FindCombinations(13, 15).Combinations, found)
+
+	stop := errors.New("stop")
+	calls := 0
+	err = calc.EachCombination(context.Background(), 13, 15, models.Constraints{}, func(models.Combination) error {
+		calls++
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, calls)
+
+	err = calc.EachCombination(context.Background(), 13, 15, models.Constraints{Exclude: []string{"XXL"}}, func(models.Combination) error {
+		return nil
+	})
+	assert.ErrorContains(t, err, `unknown size "XXL"`)
+}
+
+func TestFindCombinationsConstraints(t *testing.T) {
+	calc := NewCalculator()
+
+	tests := []struct {
+		name        string
+		points      int
+		maxTasks    int
+		constraints models.Constraints
+	}{
+		{name: "Max per size", points: 30, maxTasks: 15, constraints: models.Constraints{Max: models.TaskCount{"L": 1}}},
+		{name: "Min per size", points: 20, maxTasks: 15, constraints: models.Constraints{Min: models.TaskCount{"xs": 3}}},
+		{name: "Excluded size", points: 20, maxTasks: 15, constraints: models.Constraints{Exclude: []string{"M"}}},
+		{name: "Combined", points: 33, maxTasks: 12, constraints: models.Constraints{
+			Min:     models.TaskCount{"XS": 3, "S": 1},
+			Max:     models.TaskCount{"L": 1, "XS": 5},
+			Exclude: []string{"m"},
+		}},
+		{name: "Minimums exceed points", points: 5, maxTasks: 15, constraints: models.Constraints{Min: models.TaskCount{"L": 1}}},
+		{name: "Minimums exceed max tasks", points: 40, maxTasks: 3, constraints: models.Constraints{Min: models.TaskCount{"XS": 4}}},
+		{name: "Huge minimum", points: 40, maxTasks: 3, constraints: models.Constraints{Min: models.TaskCount{"L": math.MaxInt}}},
+		{name: "Huge minimum and max tasks", points: 40, maxTasks: math.MaxInt, constraints: models.Constraints{Min: models.TaskCount{"L": math.MaxInt}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.FindCombinationsContext(context.Background(), tt.points, tt.maxTasks, tt.constraints)
+			require.NoError(t, err)
+
+			// The pruned search finds exactly the unconstrained combinations within the bounds
+			normalized, err := calc.NormalizeConstraints(tt.constraints)
+			require.NoError(t, err)
+			want := []models.Combination{}
+			for _, combo := range calc.FindCombinations(tt.points, tt.maxTasks).Combinations {
+				ok := true
+				for _, size := range calc.Scale().Sizes {
+					lo, hi := sizeBounds(normalized, size)
+					ok = ok && combo.Counts[size.Name] >= lo && combo.Counts[size.Name] <= hi
+				}
+				if ok {
+					want = append(want, combo)
+				}
+			}
+
+			assert.Equal(t, want, result.Combinations)
+			assert.Equal(t, len(want), result.TotalFound)
+			require.NotNil(t, result.Constraints)
+			assert.Equal(t, normalized, *result.Constraints)
+		})
+	}
+
+	result, err := calc.FindCombinationsContext(context.Background(), 13, 3, models.Constraints{})
+	require.NoError(t, err)
+	assert.Nil(t, result.Constraints, "unconstrained results carry no constraints")
+}
+
+func TestNormalizeConstraints(t *testing.T) {
+	calc := NewCalculator()
+
+	normalized, err := calc.NormalizeConstraints(models.Constraints{
+		Min:     models.TaskCount{"xs": 3},
+		Max:     models.TaskCount{"l": 1},
+		Exclude: []string{"m", "S", "M"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.Constraints{
+		Min:     models.TaskCount{"XS": 3},
+		Max:     models.TaskCount{"L": 1},
+		Exclude: []string{"S", "M"},
+	}, normalized)
+
+	tests := []struct {
+		name        string
+		constraints models.Constraints
+		wantErr     string
+	}{
+		{name: "Unknown min size", constraints: models.Constraints{Min: models.TaskCount{"XXL": 1}}, wantErr: `min: unknown size "XXL"`},
+		{name: "Unknown excluded size", constraints: models.Constraints{Exclude: []string{"XXL"}}, wantErr: `exclude: unknown size "XXL"`},
+		{name: "Negative max", constraints: models.Constraints{Max: models.TaskCount{"L": -1}}, wantErr: "max: count of L must not be negative"},
+		{name: "Same size twice", constraints: models.Constraints{Max: models.TaskCount{"L": 1, "l": 2}}, wantErr: "given more than once"},
+		{name: "Min above max", constraints: models.Constraints{Min: models.TaskCount{"L": 2}, Max: models.TaskCount{"L": 1}}, wantErr: "minimum 2 exceeds maximum 1"},
+		{name: "Excluded with min", constraints: models.Constraints{Min: models.TaskCount{"M": 1}, Exclude: []string{"M"}}, wantErr: "M is excluded but has a minimum of 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := calc.NormalizeConstraints(tt.constraints)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/gr1m0h/sizely/internal/models"
+	"github.com/gr1m0h/sizely/internal/scale"
+)
+
+// NormalizeConstraints checks that constraints only name sizes of the scale, with counts that
+// can be met, and returns them keyed by the scale's size names with excluded sizes in scale order
+func (c *Calculator) NormalizeConstraints(constraints models.Constraints) (models.Constraints, error) {
+	minCounts, err := c.normalizeCounts("min", constraints.Min)
+	if err != nil {
+		return models.Constraints{}, err
+	}
+	maxCounts, err := c.normalizeCounts("max", constraints.Max)
+	if err != nil {
+		return models.Constraints{}, err
+	}
+	normalized := models.Constraints{Min: minCounts, Max: maxCounts}
+
+	for _, name := range constraints.Exclude {
+		size, ok := c.scale.Lookup(name)
+		if !ok {
+			return models.Constraints{}, fmt.Errorf("exclude: unknown size %q (expected one of %v)", name, c.scale.Names())
+		}
+		if !slices.Contains(normalized.Exclude, size.Name) {
+			normalized.Exclude = append(normalized.Exclude, size.Name)
+		}
+	}
+	slices.SortFunc(normalized.Exclude, func(a, b string) int {
+		return c.sizeIndex(a) - c.sizeIndex(b)
+	})
+
+	for _, size := range c.scale.Sizes {
+		lo, hi := sizeBounds(normalized, size)
+		if lo > hi {
+			if slices.Contains(normalized.Exclude, size.Name) {
+				return models.Constraints{}, fmt.Errorf("size %s is excluded but has a minimum of %d", size.Name, lo)
+			}
+			return models.Constraints{}, fmt.Errorf("size %s: minimum %d exceeds maximum %d", size.Name, lo, hi)
+		}
+	}
+
+	return normalized, nil
+}
+
+// normalizeCounts checks the per-size counts of a min or max constraint and keys them by the scale's size names
+func (c *Calculator) normalizeCounts(kind string, counts models.TaskCount) (models.TaskCount, error) {
+	if len(counts) == 0 {
+		return nil, nil
+	}
+
+	normalized := make(models.TaskCount, len(counts))
+	for name, count := range counts {
+		size, ok := c.scale.Lookup(name)
+		if !ok {
+			return nil, fmt.Errorf("%s: unknown size %q (expected one of %v)", kind, name, c.scale.Names())
+		}
+		if count < 0 {
+			return nil, fmt.Errorf("%s: count of %s must not be negative", kind, size.Name)
+		}
+		if _, dup := normalized[size.Name]; dup {
+			return nil, fmt.Errorf("%s: size %s is given more than once", kind, size.Name)
+		}
+		normalized[size.Name] = count
+	}
+
+	return normalized, nil
+}
+
+// sizeBounds returns the fewest and most tasks of size allowed by normalized constraints
+func sizeBounds(constraints models.Constraints, size scale.Size) (lo, hi int) {
+	lo, hi = constraints.Min[size.Name], math.MaxInt
+	if n, ok := constraints.Max[size.Name]; ok {
+		hi = n
+	}
+	if slices.Contains(constraints.Exclude, size.Name) {
+		hi = 0
+	}
+
+	return lo, hi
+}
+
+// sizeIndex returns the position of the named size in the scale
+func (c *Calculator) sizeIndex(name string) int {
+	return slices.IndexFunc(c.scale.Sizes, func(s scale.Size) bool { return s.Name == name })
+}
@@ -0,0 +1,179 @@
+package calculator
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/gr1m0h/sizely/internal/calendar"
+	"github.com/gr1m0h/sizely/internal/models"
+)
+
+// MaxForecastSprints bounds a simulated release so that a near-zero throughput cannot loop forever
+const MaxForecastSprints = 1000
+
+// DefaultTrials is the number of simulated sprints used by the Monte Carlo forecasts
+const DefaultTrials = 10000
+
+// ForecastConfidences are the confidence levels, in percent, reported by the forecasts
+var ForecastConfidences = []int{50, 85, 95}
+
+// ForecastSprint estimates how likely the team is to complete plannedPoints in one sprint.
+// Each trial resamples a randomly chosen past sprint as a whole, taking its completed points
+// as the velocity report counts them, so good and bad sprints keep their full spread rather
+// than averaging out across sizes.
+func (c *Calculator) ForecastSprint(records []models.SprintRecord, plannedPoints, trials int, rng *rand.Rand) (models.SprintForecast, error) {
+	return c.ForecastSprintContext(context.Background(), records, plannedPoints, trials, rng)
+}
+
+// ForecastSprintContext is ForecastSprint stopping the simulation with the context's error once ctx is done
+func (c *Calculator) ForecastSprintContext(ctx context.Context, records []models.SprintRecord, plannedPoints, trials int, rng *rand.Rand) (models.SprintForecast, error) {
+	if err := c.checkScale(records); err != nil {
+		return models.SprintForecast{}, err
+	}
+
+	throughputs, err := simulateThroughput(ctx, records, trials, rng)
+	if err != nil {
+		return models.SprintForecast{}, err
+	}
+
+	landed := 0
+	for _, points := range throughputs {
+		if points >= plannedPoints {
+			landed++
+		}
+	}
+
+	return models.SprintForecast{
+		PlannedPoints: plannedPoints,
+		Trials:        trials,
+		Sprints:       len(records),
+		Probability:   float64(landed) / float64(trials),
+		Percentiles:   percentiles(throughputs),
+	}, nil
+}
+
+// ForecastRelease estimates how many sprints of sprintLength days, starting on start, it takes to
+// complete the backlog tasks. Each trial resamples whole past sprints as ForecastSprint does, one
+// per simulated sprint, until the backlog's points are burned down.
+func (c *Calculator) ForecastRelease(records []models.SprintRecord, tasks models.TaskCount, start time.Time, sprintLength, trials int, rng *rand.Rand) (models.ReleaseForecast, error) {
+	return c.ForecastReleaseContext(context.Background(), records, tasks, start, sprintLength, trials, rng)
+}
+
+// ForecastReleaseContext is ForecastRelease stopping the simulation with the context's error once ctx is done
+func (c *Calculator) ForecastReleaseContext(ctx context.Context, records []models.SprintRecord, tasks models.TaskCount, start time.Time, sprintLength, trials int, rng *rand.Rand) (models.ReleaseForecast, error) {
+	if err := ctx.Err(); err != nil {
+		return models.ReleaseForecast{}, err
+	}
+	if sprintLength <= 0 {
+		return models.ReleaseForecast{}, fmt.Errorf("sprint length must be positive")
+	}
+
+	totalPoints := c.CalculatePoints(tasks)
+	if totalPoints <= 0 {
+		return models.ReleaseForecast{}, fmt.Errorf("backlog has no points to forecast")
+	}
+
+	if err := validateSimulation(records, trials); err != nil {
+		return models.ReleaseForecast{}, err
+	}
+	if err := c.checkScale(records); err != nil {
+		return models.ReleaseForecast{}, err
+	}
+	if completedTotal(records) == 0 {
+		return models.ReleaseForecast{}, fmt.Errorf("velocity history has no completed points")
+	}
+
+	sprints := make([]int, trials)
+	for i := range sprints {
+		// A trial simulates up to MaxForecastSprints sprints, so check ctx before every one
+		if err := ctx.Err(); err != nil {
+			return models.ReleaseForecast{}, err
+		}
+		for remaining := totalPoints; remaining > 0; sprints[i]++ {
+			if sprints[i] == MaxForecastSprints {
+				return models.ReleaseForecast{}, fmt.Errorf("backlog of %d points takes more than %d sprints at the recorded velocity", totalPoints, MaxForecastSprints)
+			}
+			remaining -= records[rng.Intn(len(records))].CompletedPoints
+		}
+	}
+	sort.Ints(sprints)
+
+	result := models.ReleaseForecast{
+		TotalPoints:  totalPoints,
+		TotalTasks:   tasks.Total(),
+		Start:        start.Format(calendar.DateLayout),
+		SprintLength: sprintLength,
+		Trials:       trials,
+		Sprints:      len(records),
+		Percentiles:  make([]models.ReleasePercentile, len(ForecastConfidences)),
+	}
+	for i, confidence := range ForecastConfidences {
+		// the fewest sprints that at least confidence percent of the trials finished within
+		n := sprints[(trials*confidence+99)/100-1]
+		result.Percentiles[i] = models.ReleasePercentile{
+			Confidence: confidence,
+			Sprints:    n,
+			Finish:     start.AddDate(0, 0, n*sprintLength-1).Format(calendar.DateLayout),
+		}
+	}
+
+	return result, nil
+}
+
+// completedTotal returns the points completed over all records
+func completedTotal(records []models.SprintRecord) int {
+	total := 0
+	for _, record := range records {
+		total += record.CompletedPoints
+	}
+
+	return total
+}
+
+// simulateThroughput returns the completed points of trials simulated sprints
+func simulateThroughput(ctx context.Context, records []models.SprintRecord, trials int, rng *rand.Rand) ([]int, error) {
+	if err := validateSimulation(records, trials); err != nil {
+		return nil, err
+	}
+
+	throughputs := make([]int, trials)
+	for i := range throughputs {
+		if i%cancelCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		throughputs[i] = records[rng.Intn(len(records))].CompletedPoints
+	}
+
+	return throughputs, nil
+}
+
+// validateSimulation checks that there is history to sample and a positive number of trials
+func validateSimulation(records []models.SprintRecord, trials int) error {
+	if len(records) == 0 {
+		return fmt.Errorf("no sprints recorded yet")
+	}
+	if trials <= 0 {
+		return fmt.Errorf("trials must be positive")
+	}
+
+	return nil
+}
+
+// percentiles returns, for each forecast confidence, the points reached in at least that share of samples
+func percentiles(samples []int) []models.ForecastPercentile {
+	sorted := append([]int(nil), samples...)
+	sort.Ints(sorted)
+
+	result := make([]models.ForecastPercentile, len(ForecastConfidences))
+	for i, confidence := range ForecastConfidences {
+		index := len(sorted) * (100 - confidence) / 100
+		result[i] = models.ForecastPercentile{Confidence: confidence, Points: sorted[index]}
+	}
+
+	return result
+}
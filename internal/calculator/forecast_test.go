@@ -0,0 +1,190 @@
+package calculator
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/gr1m0h/sizely/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForecastSprint(t *testing.T) {
+	calc := NewCalculator()
+
+	t.Run("steady team", func(t *testing.T) {
+		records := []models.SprintRecord{
+			{Sprint: "1", Completed: models.TaskCount{"M": 2, "L": 1}, CompletedPoints: 20},
+			{Sprint: "2", Completed: models.TaskCount{"M": 2, "L": 1}, CompletedPoints: 20},
+		}
+
+		forecast, err := calc.ForecastSprint(records, 20, 500, rand.New(rand.NewSource(1)))
+		require.NoError(t, err)
+		assert.Equal(t, 1.0, forecast.Probability)
+		assert.Equal(t, 500, forecast.Trials)
+		assert.Equal(t, 2, forecast.Sprints)
+		assert.Equal(t, []models.ForecastPercentile{
+			{Confidence: 50, Points: 20},
+			{Confidence: 85, Points: 20},
+			{Confidence: 95, Points: 20},
+		}, forecast.Percentiles)
+
+		forecast, err = calc.ForecastSprint(records, 21, 500, rand.New(rand.NewSource(1)))
+		require.NoError(t, err)
+		assert.Equal(t, 0.0, forecast.Probability)
+	})
+
+	t.Run("varying team", func(t *testing.T) {
+		records := []models.SprintRecord{
+			{Sprint: "1", Completed: models.TaskCount{"L": 1}, CompletedPoints: 10},
+			{Sprint: "2", Completed: models.TaskCount{"L": 2}, CompletedPoints: 20},
+			{Sprint: "3", Completed: models.TaskCount{"L": 3}, CompletedPoints: 30},
+		}
+
+		forecast, err := calc.ForecastSprint(records, 20, 30000, rand.New(rand.NewSource(1)))
+		require.NoError(t, err)
+		assert.InDelta(t, 2.0/3, forecast.Probability, 0.02)
+		assert.Equal(t, 20, forecast.Percentiles[0].Points)
+		assert.Equal(t, 10, forecast.Percentiles[1].Points)
+		assert.Equal(t, 10, forecast.Percentiles[2].Points)
+	})
+
+	t.Run("whole sprint sampling", func(t *testing.T) {
+		// One sprint finished three L, the other ten XS: every simulated sprint is one of the
+		// two, so the throughput is 10 or 30 points with even odds and never 0 or 40
+		records := []models.SprintRecord{
+			{Sprint: "1", Completed: models.TaskCount{"L": 3}, CompletedPoints: 30},
+			{Sprint: "2", Completed: models.TaskCount{"XS": 10}, CompletedPoints: 10},
+		}
+		rng := rand.New(rand.NewSource(1))
+
+		for _, tt := range []struct {
+			planned     int
+			probability float64
+		}{{1, 1}, {10, 1}, {11, 0.5}, {30, 0.5}, {31, 0}} {
+			forecast, err := calc.ForecastSprint(records, tt.planned, 20000, rng)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.probability, forecast.Probability, 0.02, "%d points", tt.planned)
+			// drawing sizes independently would leave a quarter of the sprints at 0 points
+			assert.Equal(t, []models.ForecastPercentile{
+				{Confidence: 85, Points: 10},
+				{Confidence: 95, Points: 10},
+			}, forecast.Percentiles[1:])
+		}
+	})
+
+	t.Run("history on another scale", func(t *testing.T) {
+		// points of another scale do not add up with the current scale's
+		records := []models.SprintRecord{
+			{Sprint: "1", Scale: "tshirt", Completed: models.TaskCount{"L": 1}, CompletedPoints: 10},
+			{Sprint: "2", Scale: "fibonacci", Completed: models.TaskCount{"XL": 2}, CompletedPoints: 16},
+		}
+
+		_, err := calc.ForecastSprint(records, 16, 100, rand.New(rand.NewSource(1)))
+		assert.ErrorContains(t, err, "sprint 2 was recorded on the fibonacci scale, not tshirt")
+
+		_, err = calc.ForecastRelease(records, models.TaskCount{"L": 2}, time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), 14, 100, rand.New(rand.NewSource(1)))
+		assert.ErrorContains(t, err, "single scale")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := calc.ForecastSprint(nil, 10, 100, rand.New(rand.NewSource(1)))
+		assert.ErrorContains(t, err, "no sprints recorded")
+
+		_, err = calc.ForecastSprint([]models.SprintRecord{{Sprint: "1"}}, 10, 0, rand.New(rand.NewSource(1)))
+		assert.ErrorContains(t, err, "trials must be positive")
+	})
+}
+
+func TestForecastRelease(t *testing.T) {
+	calc := NewCalculator()
+	start := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	t.Run("steady team", func(t *testing.T) {
+		records := []models.SprintRecord{
+			{Sprint: "1", Completed: models.TaskCount{"M": 2}, CompletedPoints: 10},
+			{Sprint: "2", Completed: models.TaskCount{"M": 2}, CompletedPoints: 10},
+		}
+
+		forecast, err := calc.ForecastRelease(records, models.TaskCount{"m": 3, "l": 2}, start, 14, 200, rand.New(rand.NewSource(1)))
+		require.NoError(t, err)
+		assert.Equal(t, 35, forecast.TotalPoints)
+		assert.Equal(t, 5, forecast.TotalTasks)
+		assert.Equal(t, "2026-10-19", forecast.Start)
+		assert.Equal(t, []models.ReleasePercentile{
+			{Confidence: 50, Sprints: 4, Finish: "2026-12-13"},
+			{Confidence: 85, Sprints: 4, Finish: "2026-12-13"},
+			{Confidence: 95, Sprints: 4, Finish: "2026-12-13"},
+		}, forecast.Percentiles)
+	})
+
+	t.Run("varying team", func(t *testing.T) {
+		records := []models.SprintRecord{
+			{Sprint: "1", Completed: models.TaskCount{"L": 1}, CompletedPoints: 10},
+			{Sprint: "2", Completed: models.TaskCount{"L": 3}, CompletedPoints: 30},
+		}
+
+		forecast, err := calc.ForecastRelease(records, models.TaskCount{"L": 4}, start, 7, 10000, rand.New(rand.NewSource(1)))
+		require.NoError(t, err)
+
+		sprints := make([]int, len(forecast.Percentiles))
+		for i, p := range forecast.Percentiles {
+			sprints[i] = p.Sprints
+		}
+		// two sprints finish 4 L with 3/4 chance, four sprints always do
+		assert.Equal(t, []int{2, 3, 4}, sprints)
+		assert.Equal(t, "2026-11-15", forecast.Percentiles[2].Finish)
+	})
+
+	t.Run("whole sprint sampling", func(t *testing.T) {
+		// Every simulated sprint completes 30 or 10 points with even odds: 60 points are done
+		// within four sprints with chance 15/16 and within five with 31/32, and never take more
+		// than six
+		records := []models.SprintRecord{
+			{Sprint: "1", Completed: models.TaskCount{"L": 3}, CompletedPoints: 30},
+			{Sprint: "2", Completed: models.TaskCount{"XS": 10}, CompletedPoints: 10},
+		}
+
+		forecast, err := calc.ForecastRelease(records, models.TaskCount{"L": 6}, start, 14, 20000, rand.New(rand.NewSource(1)))
+		require.NoError(t, err)
+		assert.Equal(t, 4, forecast.Percentiles[1].Sprints)
+		assert.Equal(t, 5, forecast.Percentiles[2].Sprints)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		records := []models.SprintRecord{{Sprint: "1", Completed: models.TaskCount{"S": 1}, CompletedPoints: 3}}
+		rng := rand.New(rand.NewSource(1))
+
+		_, err := calc.ForecastRelease(records, models.TaskCount{}, start, 14, 100, rng)
+		assert.ErrorContains(t, err, "no points")
+
+		_, err = calc.ForecastRelease(records, models.TaskCount{"S": 1}, start, 0, 100, rng)
+		assert.ErrorContains(t, err, "sprint length must be positive")
+
+		_, err = calc.ForecastRelease(nil, models.TaskCount{"S": 1}, start, 14, 100, rng)
+		assert.ErrorContains(t, err, "no sprints recorded")
+
+		_, err = calc.ForecastRelease([]models.SprintRecord{{Sprint: "1"}}, models.TaskCount{"S": 1}, start, 14, 100, rng)
+		assert.ErrorContains(t, err, "no completed points")
+
+		_, err = calc.ForecastRelease(records, models.TaskCount{"L": 400}, start, 14, 100, rng)
+		assert.ErrorContains(t, err, "more than 1000 sprints")
+	})
+}
+
+func TestForecastContext(t *testing.T) {
+	calc := NewCalculator()
+	records := []models.SprintRecord{{Sprint: "1", Completed: models.TaskCount{"M": 2}, CompletedPoints: 10}}
+	start := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := calc.ForecastSprintContext(ctx, records, 10, 100, rand.New(rand.NewSource(1)))
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = calc.ForecastReleaseContext(ctx, records, models.TaskCount{"L": 2}, start, 14, 100, rand.New(rand.NewSource(1)))
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,291 @@
+package calculator
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/gr1m0h/sizely/internal/models"
+)
+
+// PlanBacklog selects backlog items whose points best fit targetPoints without exceeding it
+// or maxTasks. Items are given in priority order: among the selections reaching the best
+// point total, the one preferring earlier items is chosen. An item is only selected together
+// with all of its prerequisites, which are pulled ahead of it in the plan.
+func (c *Calculator) PlanBacklog(items []models.BacklogItem, targetPoints, maxTasks int) (models.PlanResult, error) {
+	return c.PlanBacklogContext(context.Background(), items, targetPoints, maxTasks)
+}
+
+// PlanBacklogContext is PlanBacklog stopping the search with the context's error once ctx is done
+func (c *Calculator) PlanBacklogContext(ctx context.Context, items []models.BacklogItem, targetPoints, maxTasks int) (models.PlanResult, error) {
+	if err := ctx.Err(); err != nil {
+		return models.PlanResult{}, err
+	}
+	if targetPoints <= 0 {
+		return models.PlanResult{}, fmt.Errorf("points must be positive")
+	}
+	if maxTasks <= 0 {
+		return models.PlanResult{}, fmt.Errorf("max tasks must be positive")
+	}
+	if _, err := c.CountBacklog(items); err != nil {
+		return models.PlanResult{}, err
+	}
+
+	order, err := dependencyOrder(items)
+	if err != nil {
+		return models.PlanResult{}, err
+	}
+
+	// planned holds the items in priority order
+	planned := make([]models.PlannedItem, len(items))
+	position := make(map[string]int, len(items))
+	totalPoints := 0
+	for i, item := range items {
+		size, _ := c.scale.Lookup(item.Size)
+		planned[i] = models.PlannedItem{BacklogItem: item, Rank: i + 1, Points: size.Points}
+		totalPoints += size.Points
+		if item.ID != "" {
+			position[item.ID] = i
+		}
+	}
+
+	// No selection can exceed the whole backlog, so the search never needs a larger budget
+	budget := min(targetPoints, totalPoints)
+
+	// prerequisites[i] holds every direct and transitive prerequisite of planned[i]
+	prerequisites := make([][]int, len(planned))
+	for i := range planned {
+		prerequisites[i] = closure(planned, position, i)
+	}
+
+	// minTasks[i][p] is the fewest items from planned[i:] that add up to exactly p points,
+	// ignoring dependencies; it bounds how much the rest of the plan can still add
+	minTasks := make([][]int, len(planned)+1)
+	for i := range minTasks {
+		minTasks[i] = make([]int, budget+1)
+		for p := range minTasks[i] {
+			minTasks[i][p] = math.MaxInt
+		}
+		minTasks[i][0] = 0
+	}
+	for i := len(planned) - 1; i >= 0; i-- {
+		for p := 0; p <= budget; p++ {
+			minTasks[i][p] = minTasks[i+1][p]
+			if rest := p - planned[i].Points; rest >= 0 && minTasks[i+1][rest] != math.MaxInt {
+				minTasks[i][p] = min(minTasks[i][p], minTasks[i+1][rest]+1)
+			}
+		}
+	}
+	reachable := func(i, left, tasksLeft int) int {
+		for p := min(left, budget); p > 0; p-- {
+			if minTasks[i][p] <= tasksLeft {
+				return p
+			}
+		}
+		return 0
+	}
+
+	// Search selections depth first in priority order, trying to include each item (together
+	// with the prerequisites it pulls in) before skipping it, so the first selection found for
+	// the best total is the one that prefers higher priority items
+	chosen := make([]bool, len(planned))
+	best, bestChosen := -1, make([]bool, len(planned))
+
+	steps := 0
+	var search func(i, points, tasks int)
+	search = func(i, points, tasks int) {
+		if steps++; steps%cancelCheckInterval == 0 && err == nil {
+			err = ctx.Err()
+		}
+		if err != nil {
+			return
+		}
+		if points > best {
+			best = points
+			copy(bestChosen, chosen)
+		}
+		if best == targetPoints || i == len(planned) {
+			return
+		}
+		if chosen[i] {
+			search(i+1, points, tasks)
+			return
+		}
+		if points+reachable(i, targetPoints-points, maxTasks-tasks) <= best {
+			return
+		}
+
+		// Prerequisites of higher priority were already decided; a skipped one blocks this item
+		bundle, bundlePoints, blocked := []int{i}, planned[i].Points, false
+		for _, j := range prerequisites[i] {
+			if chosen[j] {
+				continue
+			}
+			if j < i {
+				blocked = true
+				break
+			}
+			bundle = append(bundle, j)
+			bundlePoints += planned[j].Points
+		}
+
+		if !blocked && points+bundlePoints <= targetPoints && tasks+len(bundle) <= maxTasks {
+			for _, j := range bundle {
+				chosen[j] = true
+			}
+			search(i+1, points+bundlePoints, tasks+len(bundle))
+			for _, j := range bundle {
+				chosen[j] = false
+			}
+		}
+		search(i+1, points, tasks)
+	}
+	search(0, 0, 0)
+	if err != nil {
+		return models.PlanResult{}, err
+	}
+
+	result := models.PlanResult{
+		TargetPoints: targetPoints,
+		MaxTasks:     maxTasks,
+		Selected:     []models.PlannedItem{},
+		Deferred:     []models.PlannedItem{},
+	}
+
+	// Selected items are listed in the order they can be done, deferred ones by priority
+	for _, i := range order {
+		item := planned[i]
+		item.Requires = orderedIDs(planned, prerequisites[i], order)
+		planned[i] = item
+		if bestChosen[i] {
+			result.Selected = append(result.Selected, item)
+			result.SelectedPoints += item.Points
+		}
+	}
+	for i, item := range planned {
+		if !bestChosen[i] {
+			item.Reason = deferReason(result, item, position, bestChosen)
+			result.Deferred = append(result.Deferred, item)
+		}
+	}
+
+	return result, nil
+}
+
+// dependencyOrder returns item indexes in priority order with every item's prerequisites
+// placed before it, rejecting unknown dependencies and cycles
+func dependencyOrder(items []models.BacklogItem) ([]int, error) {
+	index := make(map[string]int, len(items))
+	for i, item := range items {
+		if item.ID != "" {
+			index[item.ID] = i
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make([]int, len(items))
+	order := make([]int, 0, len(items))
+	var path []string
+
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case done:
+			return nil
+		case visiting:
+			start := 0
+			for path[start] != items[i].ID {
+				start++
+			}
+			cycle := append(append([]string(nil), path[start:]...), items[i].ID)
+			return fmt.Errorf("dependency cycle: %s", strings.Join(cycle, " → "))
+		}
+
+		state[i] = visiting
+		path = append(path, items[i].ID)
+		for _, dep := range items[i].DependsOn {
+			j, ok := index[dep]
+			if !ok {
+				return fmt.Errorf("backlog item %s depends on unknown item %q", itemLabel(i, items[i]), dep)
+			}
+			if err := visit(j); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[i] = done
+		order = append(order, i)
+
+		return nil
+	}
+
+	for i := range items {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+
+	return order, nil
+}
+
+// closure returns the indexes of every direct and transitive prerequisite of planned[i]
+func closure(planned []models.PlannedItem, position map[string]int, i int) []int {
+	seen := make(map[int]bool)
+	var result []int
+
+	var collect func(j int)
+	collect = func(j int) {
+		for _, dep := range planned[j].DependsOn {
+			k := position[dep]
+			if !seen[k] {
+				seen[k] = true
+				result = append(result, k)
+				collect(k)
+			}
+		}
+	}
+	collect(i)
+
+	return result
+}
+
+// orderedIDs returns the IDs of the given items, sorted by their place in order
+func orderedIDs(planned []models.PlannedItem, indexes []int, order []int) []string {
+	wanted := make(map[int]bool, len(indexes))
+	for _, i := range indexes {
+		wanted[i] = true
+	}
+
+	var result []string
+	for _, i := range order {
+		if wanted[i] {
+			result = append(result, planned[i].ID)
+		}
+	}
+
+	return result
+}
+
+// deferReason explains why an item was left out of the plan
+func deferReason(result models.PlanResult, item models.PlannedItem, position map[string]int, chosen []bool) string {
+	var blockers []string
+	for _, dep := range item.Requires {
+		if !chosen[position[dep]] {
+			blockers = append(blockers, dep)
+		}
+	}
+	if len(blockers) > 0 {
+		return fmt.Sprintf("blocked by deferred %s", strings.Join(blockers, ", "))
+	}
+
+	if len(result.Selected) >= result.MaxTasks {
+		return fmt.Sprintf("task limit of %d reached", result.MaxTasks)
+	}
+
+	return fmt.Sprintf("needs %d points, only %d left", item.Points, result.TargetPoints-result.SelectedPoints)
+}
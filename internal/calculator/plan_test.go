@@ -0,0 +1,197 @@
+package calculator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gr1m0h/sizely/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ids(items []models.PlannedItem) []string {
+	result := make([]string, len(items))
+	for i, item := range items {
+		result[i] = item.ID
+	}
+
+	return result
+}
+
+func TestPlanBacklog(t *testing.T) {
+	calc := NewCalculator()
+	backlog := []models.BacklogItem{
+		{ID: "A", Size: "L"},  // 10
+		{ID: "B", Size: "M"},  // 5
+		{ID: "C", Size: "L"},  // 10
+		{ID: "D", Size: "S"},  // 3
+		{ID: "E", Size: "XS"}, // 1
+		{ID: "F", Size: "S"},  // 3
+	}
+
+	tests := []struct {
+		name         string
+		targetPoints int
+		maxTasks     int
+		selected     []string
+		points       int
+	}{
+		{name: "Exact fit prefers priority", targetPoints: 15, maxTasks: 10, selected: []string{"A", "B"}, points: 15},
+		{name: "Skips items that overshoot", targetPoints: 19, maxTasks: 10, selected: []string{"A", "B", "D", "E"}, points: 19},
+		{name: "Best fit below target", targetPoints: 40, maxTasks: 10, selected: []string{"A", "B", "C", "D", "E", "F"}, points: 32},
+		{name: "Task limit", targetPoints: 30, maxTasks: 2, selected: []string{"A", "C"}, points: 20},
+		{name: "Lower priority item for better fit", targetPoints: 4, maxTasks: 10, selected: []string{"D", "E"}, points: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.PlanBacklog(backlog, tt.targetPoints, tt.maxTasks)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.selected, ids(result.Selected))
+			assert.Equal(t, tt.points, result.SelectedPoints)
+			assert.Len(t, result.Deferred, len(backlog)-len(tt.selected))
+			assert.LessOrEqual(t, result.SelectedPoints, tt.targetPoints)
+			for _, item := range result.Deferred {
+				assert.NotEmpty(t, item.Reason)
+			}
+		})
+	}
+}
+
+func TestPlanBacklogDetails(t *testing.T) {
+	calc := NewCalculator()
+
+	result, err := calc.PlanBacklog([]models.BacklogItem{
+		{ID: "A", Title: "Big", Size: "L"},
+		{ID: "B", Title: "Small", Size: "xs"},
+		{ID: "C", Title: "Medium", Size: "M"},
+	}, 6, 1)
+	require.NoError(t, err)
+
+	require.Len(t, result.Selected, 1)
+	assert.Equal(t, models.PlannedItem{BacklogItem: models.BacklogItem{ID: "C", Title: "Medium", Size: "M"}, Rank: 3, Points: 5}, result.Selected[0])
+	assert.Equal(t, "task limit of 1 reached", result.Deferred[0].Reason)
+
+	result, err = calc.PlanBacklog([]models.BacklogItem{{ID: "A", Size: "L"}, {ID: "B", Size: "S"}}, 5, 5)
+	require.NoError(t, err)
+	assert.Equal(t, "needs 10 points, only 2 left", result.Deferred[0].Reason)
+}
+
+func TestPlanBacklogErrors(t *testing.T) {
+	calc := NewCalculator()
+
+	_, err := calc.PlanBacklog(nil, 0, 5)
+	assert.ErrorContains(t, err, "points must be positive")
+
+	_, err = calc.PlanBacklog(nil, 5, 0)
+	assert.ErrorContains(t, err, "max tasks must be positive")
+
+	_, err = calc.PlanBacklog([]models.BacklogItem{{ID: "A", Size: "XL"}}, 5, 5)
+	assert.ErrorContains(t, err, "unknown size")
+
+	result, err := calc.PlanBacklog(nil, 5, 5)
+	require.NoError(t, err)
+	assert.Empty(t, result.Selected)
+}
+
+func TestPlanBacklogDependencies(t *testing.T) {
+	calc := NewCalculator()
+
+	t.Run("Pulls prerequisites in ahead of dependents", func(t *testing.T) {
+		result, err := calc.PlanBacklog([]models.BacklogItem{
+			{ID: "API-15", Size: "L", DependsOn: []string{"API-12"}},
+			{ID: "WEB-3", Size: "S"},
+			{ID: "API-12", Size: "M", DependsOn: []string{"OPS-2"}},
+			{ID: "OPS-2", Size: "XS"},
+		}, 16, 10)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"OPS-2", "API-12", "API-15"}, ids(result.Selected))
+		assert.Equal(t, 16, result.SelectedPoints)
+		assert.Equal(t, []string{"OPS-2", "API-12"}, result.Selected[2].Requires)
+		assert.Equal(t, 1, result.Selected[2].Rank)
+	})
+
+	t.Run("Skips dependents whose prerequisites do not fit", func(t *testing.T) {
+		result, err := calc.PlanBacklog([]models.BacklogItem{
+			{ID: "A", Size: "M", DependsOn: []string{"B"}},
+			{ID: "B", Size: "L"},
+			{ID: "C", Size: "M"},
+			{ID: "D", Size: "S"},
+		}, 12, 10)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"B"}, ids(result.Selected))
+		assert.Equal(t, []string{"A", "C", "D"}, ids(result.Deferred))
+		assert.Equal(t, "needs 5 points, only 2 left", result.Deferred[0].Reason)
+	})
+
+	t.Run("Reports blocked items", func(t *testing.T) {
+		result, err := calc.PlanBacklog([]models.BacklogItem{
+			{ID: "A", Size: "XS", DependsOn: []string{"B"}},
+			{ID: "B", Size: "L"},
+			{ID: "C", Size: "S"},
+		}, 5, 10)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"C"}, ids(result.Selected))
+		assert.Equal(t, "blocked by deferred B", result.Deferred[0].Reason)
+	})
+
+	t.Run("Prerequisites only win priority through their dependents", func(t *testing.T) {
+		result, err := calc.PlanBacklog([]models.BacklogItem{
+			{ID: "A", Size: "L", DependsOn: []string{"Z"}},
+			{ID: "B", Size: "XS"},
+			{ID: "Z", Size: "XS"},
+		}, 1, 10)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"B"}, ids(result.Selected))
+		assert.Equal(t, "blocked by deferred Z", result.Deferred[0].Reason)
+	})
+
+	t.Run("Cycle", func(t *testing.T) {
+		_, err := calc.PlanBacklog([]models.BacklogItem{
+			{ID: "A", Size: "S", DependsOn: []string{"B"}},
+			{ID: "B", Size: "S", DependsOn: []string{"C"}},
+			{ID: "C", Size: "S", DependsOn: []string{"A"}},
+		}, 10, 10)
+		assert.EqualError(t, err, "dependency cycle: A → B → C → A")
+	})
+
+	t.Run("Unknown dependency", func(t *testing.T) {
+		_, err := calc.PlanBacklog([]models.BacklogItem{{ID: "A", Size: "S", DependsOn: []string{"Z"}}}, 10, 10)
+		assert.EqualError(t, err, `backlog item A depends on unknown item "Z"`)
+	})
+}
+
+func TestPlanBacklogHugeTarget(t *testing.T) {
+	calc := NewCalculator()
+
+	result, err := calc.PlanBacklog([]models.BacklogItem{
+		{ID: "A", Size: "L"},
+		{ID: "B", Size: "M"},
+		{ID: "C", Size: "S"},
+	}, 200_000_000, 10)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"A", "B", "C"}, ids(result.Selected))
+	assert.Equal(t, 18, result.SelectedPoints)
+	assert.Equal(t, 200_000_000, result.TargetPoints)
+}
+
+func TestPlanBacklogContext(t *testing.T) {
+	calc := NewCalculator()
+	backlog := []models.BacklogItem{{ID: "A", Size: "L"}, {ID: "B", Size: "M"}}
+
+	result, err := calc.PlanBacklogContext(context.Background(), backlog, 12, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"A"}, ids(result.Selected))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = calc.PlanBacklogContext(ctx, backlog, 12, 10)
+	assert.ErrorIs(t, err, context.Canceled)
+}
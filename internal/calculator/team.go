@@ -0,0 +1,104 @@
+package calculator
+
+import (
+	"fmt"
+
+	"github.com/gr1m0h/sizely/internal/models"
+)
+
+const (
+	// UnderCommitmentRatio is the share of capacity below which a plan counts as under-committed
+	UnderCommitmentRatio = 0.8
+	// OverCommitmentRatio is the share of capacity above which a plan counts as over-committed
+	OverCommitmentRatio = 1.0
+)
+
+// CalculateTeamCapacity calculates how many points the team can deliver and compares it with plannedPoints.
+// Each member contributes days × focus factor × points per day; members without days use the
+// team's sprint days and members without a focus factor use the team's (1.0 when unset).
+func (c *Calculator) CalculateTeamCapacity(team models.Team, plannedPoints int) (models.TeamCapacity, error) {
+	if err := validateTeam(team); err != nil {
+		return models.TeamCapacity{}, err
+	}
+
+	teamFocus := team.FocusFactor
+	if teamFocus == 0 {
+		teamFocus = 1
+	}
+
+	result := models.TeamCapacity{
+		Team:          team.Name,
+		PointsPerDay:  team.PointsPerDay,
+		Members:       make([]models.MemberCapacity, 0, len(team.Members)),
+		PlannedPoints: plannedPoints,
+	}
+
+	for _, member := range team.Members {
+		days := team.SprintDays
+		if member.Days != nil {
+			days = *member.Days
+		}
+		focus := member.FocusFactor
+		if focus == 0 {
+			focus = teamFocus
+		}
+
+		points := days * focus * team.PointsPerDay
+		result.Members = append(result.Members, models.MemberCapacity{
+			Name:        member.Name,
+			Days:        days,
+			FocusFactor: focus,
+			Points:      points,
+		})
+		result.CapacityPoints += points
+	}
+
+	result.Commitment = models.CommitmentBalanced
+	if result.CapacityPoints > 0 {
+		result.Utilization = float64(plannedPoints) / result.CapacityPoints
+	}
+
+	switch {
+	case float64(plannedPoints) > result.CapacityPoints*OverCommitmentRatio:
+		result.Commitment = models.CommitmentOver
+	case float64(plannedPoints) < result.CapacityPoints*UnderCommitmentRatio:
+		result.Commitment = models.CommitmentUnder
+	}
+
+	return result, nil
+}
+
+// validateTeam checks that the team definition can produce a capacity
+func validateTeam(team models.Team) error {
+	if team.PointsPerDay <= 0 {
+		return fmt.Errorf("team points_per_day must be positive")
+	}
+	if team.FocusFactor < 0 || team.FocusFactor > 1 {
+		return fmt.Errorf("team focus_factor must be between 0 and 1")
+	}
+	if team.SprintDays < 0 {
+		return fmt.Errorf("team sprint_days must not be negative")
+	}
+	if len(team.Members) == 0 {
+		return fmt.Errorf("team must have at least one member")
+	}
+
+	for i, member := range team.Members {
+		name := member.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", i+1)
+		}
+
+		if member.Days != nil && *member.Days < 0 {
+			return fmt.Errorf("member %s: days must not be negative", name)
+		}
+		if member.FocusFactor < 0 || member.FocusFactor > 1 {
+			return fmt.Errorf("member %s: focus_factor must be between 0 and 1", name)
+		}
+		if member.Days == nil && team.SprintDays == 0 {
+			return fmt.Errorf("member %s: set days or the team's sprint_days", name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package calculator
+
+import (
+	"testing"
+
+	"github.com/gr1m0h/sizely/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func days(d float64) *float64 {
+	return &d
+}
+
+func TestCalculateTeamCapacity(t *testing.T) {
+	calc := NewCalculator()
+	team := models.Team{
+		Name:         "Platform",
+		PointsPerDay: 2,
+		FocusFactor:  0.5,
+		SprintDays:   10,
+		Members: []models.Member{
+			{Name: "Alice"},
+			{Name: "Bob", Days: days(6), FocusFactor: 0.75},
+			{Name: "Carol", Days: days(0)},
+		},
+	}
+
+	result, err := calc.CalculateTeamCapacity(team, 18)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Platform", result.Team)
+	assert.Equal(t, []models.MemberCapacity{
+		{Name: "Alice", Days: 10, FocusFactor: 0.5, Points: 10},
+		{Name: "Bob", Days: 6, FocusFactor: 0.75, Points: 9},
+		{Name: "Carol", Days: 0, FocusFactor: 0.5, Points: 0},
+	}, result.Members)
+	assert.InDelta(t, 19, result.CapacityPoints, 1e-9)
+	assert.InDelta(t, 18.0/19, result.Utilization, 1e-9)
+	assert.Equal(t, models.CommitmentBalanced, result.Commitment)
+}
+
+func TestCommitment(t *testing.T) {
+	calc := NewCalculator()
+	team := models.Team{PointsPerDay: 1, SprintDays: 10, Members: []models.Member{{Name: "Alice"}, {Name: "Bob"}}}
+
+	tests := []struct {
+		planned int
+		want    models.Commitment
+	}{
+		{planned: 15, want: models.CommitmentUnder},
+		{planned: 16, want: models.CommitmentBalanced},
+		{planned: 20, want: models.CommitmentBalanced},
+		{planned: 21, want: models.CommitmentOver},
+	}
+
+	for _, tt := range tests {
+		result, err := calc.CalculateTeamCapacity(team, tt.planned)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, result.Commitment, "planned %d", tt.planned)
+	}
+}
+
+func TestValidateTeam(t *testing.T) {
+	calc := NewCalculator()
+	member := []models.Member{{Name: "Alice", Days: days(5)}}
+
+	tests := []struct {
+		name    string
+		team    models.Team
+		wantErr string
+	}{
+		{name: "Missing velocity", team: models.Team{Members: member}, wantErr: "points_per_day must be positive"},
+		{name: "Focus out of range", team: models.Team{PointsPerDay: 1, FocusFactor: 1.5, Members: member}, wantErr: "focus_factor must be between 0 and 1"},
+		{name: "No members", team: models.Team{PointsPerDay: 1}, wantErr: "at least one member"},
+		{name: "Negative days", team: models.Team{PointsPerDay: 1, Members: []models.Member{{Name: "Bob", Days: days(-1)}}}, wantErr: "member Bob: days must not be negative"},
+		{name: "No days", team: models.Team{PointsPerDay: 1, Members: []models.Member{{}}}, wantErr: "member #1: set days"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := calc.CalculateTeamCapacity(tt.team, 10)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
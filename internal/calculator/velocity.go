@@ -0,0 +1,131 @@
+package calculator
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gr1m0h/sizely/internal/models"
+)
+
+// DefaultVelocityWindow is the number of recent sprints used for the rolling average
+const DefaultVelocityWindow = 3
+
+// CalculateVelocity calculates velocity statistics from sprint records ordered oldest first.
+// Velocity is the completed points of a sprint; the rolling average covers the last window sprints
+// and the trend is the least-squares slope of velocity in points per sprint.
+func (c *Calculator) CalculateVelocity(records []models.SprintRecord, window int) (models.VelocityReport, error) {
+	if len(records) == 0 {
+		return models.VelocityReport{}, fmt.Errorf("no sprints recorded yet")
+	}
+	if window <= 0 {
+		return models.VelocityReport{}, fmt.Errorf("velocity window must be positive")
+	}
+	if err := c.checkScale(records); err != nil {
+		return models.VelocityReport{}, err
+	}
+	if window > len(records) {
+		window = len(records)
+	}
+
+	velocities := make([]float64, len(records))
+	planned, completed := 0, 0
+	for i, record := range records {
+		velocities[i] = float64(record.CompletedPoints)
+		planned += record.PlannedPoints
+		completed += record.CompletedPoints
+	}
+
+	report := models.VelocityReport{
+		Sprints:        records,
+		Average:        mean(velocities),
+		Median:         median(velocities),
+		Window:         window,
+		RollingAverage: mean(velocities[len(velocities)-window:]),
+		Trend:          slope(velocities),
+		PerSize:        c.sizeVelocities(records),
+	}
+	if planned > 0 {
+		report.CompletionRate = float64(completed) / float64(planned)
+	}
+
+	return report, nil
+}
+
+// sizeVelocities averages the planned and completed tasks per sprint for each size in scale order
+func (c *Calculator) sizeVelocities(records []models.SprintRecord) []models.SizeVelocity {
+	var result []models.SizeVelocity
+	for _, size := range c.scale.Sizes {
+		planned, completed := 0, 0
+		for _, record := range records {
+			planned += record.Planned[size.Name]
+			completed += record.Completed[size.Name]
+		}
+		if planned == 0 && completed == 0 {
+			continue
+		}
+
+		result = append(result, models.SizeVelocity{
+			Size:             size.Name,
+			AveragePlanned:   float64(planned) / float64(len(records)),
+			AverageCompleted: float64(completed) / float64(len(records)),
+		})
+	}
+
+	return result
+}
+
+// mean returns the arithmetic mean of values
+func mean(values []float64) float64 {
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+
+	return sum / float64(len(values))
+}
+
+// median returns the middle value of values, averaging the two middle values for an even count
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+
+	return sorted[mid]
+}
+
+// slope returns the least-squares slope of values against their index, 0 for fewer than two values
+func slope(values []float64) float64 {
+	n := float64(len(values))
+	if n < 2 {
+		return 0
+	}
+
+	xMean := (n - 1) / 2
+	yMean := mean(values)
+
+	var num, den float64
+	for i, v := range values {
+		dx := float64(i) - xMean
+		num += dx * (v - yMean)
+		den += dx * dx
+	}
+
+	return num / den
+}
+
+// checkScale rejects records made on another scale than the calculator's, whose points do not
+// add up with its points; records that do not name their scale are assumed to match
+func (c *Calculator) checkScale(records []models.SprintRecord) error {
+	for _, record := range records {
+		if record.Scale != "" && record.Scale != c.scale.Name {
+			return fmt.Errorf("sprint %s was recorded on the %s scale, not %s; velocity and forecasts need a history on a single scale",
+				record.Sprint, record.Scale, c.scale.Name)
+		}
+	}
+
+	return nil
+}
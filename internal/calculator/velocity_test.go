@@ -0,0 +1,70 @@
+package calculator
+
+import (
+	"testing"
+
+	"github.com/gr1m0h/sizely/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateVelocity(t *testing.T) {
+	calc := NewCalculator()
+	records := []models.SprintRecord{
+		{Sprint: "1", Planned: models.TaskCount{"S": 2, "M": 2}, Completed: models.TaskCount{"S": 2, "M": 1}, PlannedPoints: 16, CompletedPoints: 11},
+		{Sprint: "2", Planned: models.TaskCount{"M": 2, "L": 1}, Completed: models.TaskCount{"M": 2, "L": 1}, PlannedPoints: 20, CompletedPoints: 20},
+		{Sprint: "3", Planned: models.TaskCount{"XS": 4, "L": 1}, Completed: models.TaskCount{"XS": 4}, PlannedPoints: 14, CompletedPoints: 4},
+		{Sprint: "4", Planned: models.TaskCount{"M": 3, "L": 1}, Completed: models.TaskCount{"M": 3, "L": 1}, PlannedPoints: 25, CompletedPoints: 25},
+	}
+
+	report, err := calc.CalculateVelocity(records, 3)
+	require.NoError(t, err)
+
+	assert.Equal(t, 15.0, report.Average)
+	assert.Equal(t, 15.5, report.Median)
+	assert.Equal(t, 3, report.Window)
+	assert.InDelta(t, 49.0/3, report.RollingAverage, 1e-9)
+	assert.InDelta(t, 2.6, report.Trend, 1e-9)
+	assert.InDelta(t, 60.0/75, report.CompletionRate, 1e-9)
+	assert.Equal(t, []models.SizeVelocity{
+		{Size: "XS", AveragePlanned: 1, AverageCompleted: 1},
+		{Size: "S", AveragePlanned: 0.5, AverageCompleted: 0.5},
+		{Size: "M", AveragePlanned: 1.75, AverageCompleted: 1.5},
+		{Size: "L", AveragePlanned: 0.75, AverageCompleted: 0.5},
+	}, report.PerSize)
+}
+
+func TestCalculateVelocityWindow(t *testing.T) {
+	calc := NewCalculator()
+	records := []models.SprintRecord{
+		{Sprint: "1", PlannedPoints: 10, CompletedPoints: 8},
+		{Sprint: "2", PlannedPoints: 10, CompletedPoints: 12},
+	}
+
+	report, err := calc.CalculateVelocity(records, 5)
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Window)
+	assert.Equal(t, 10.0, report.RollingAverage)
+	assert.Equal(t, 4.0, report.Trend)
+
+	report, err = calc.CalculateVelocity(records[:1], 3)
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, report.Trend)
+
+	_, err = calc.CalculateVelocity(nil, 3)
+	assert.ErrorContains(t, err, "no sprints recorded")
+
+	_, err = calc.CalculateVelocity(records, 0)
+	assert.ErrorContains(t, err, "window must be positive")
+}
+
+func TestCalculateVelocityScale(t *testing.T) {
+	calc := NewCalculator()
+
+	// records without a scale predate it and count as the current scale
+	_, err := calc.CalculateVelocity([]models.SprintRecord{{Sprint: "1", CompletedPoints: 8}, {Sprint: "2", Scale: "tshirt", CompletedPoints: 10}}, 3)
+	require.NoError(t, err)
+
+	_, err = calc.CalculateVelocity([]models.SprintRecord{{Sprint: "1", Scale: "tshirt", CompletedPoints: 8}, {Sprint: "2", Scale: "fibonacci", CompletedPoints: 13}}, 3)
+	assert.ErrorContains(t, err, "sprint 2 was recorded on the fibonacci scale, not tshirt")
+}
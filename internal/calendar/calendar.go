@@ -0,0 +1,287 @@
+package calendar
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gr1m0h/sizely/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// DateLayout is the format of dates in calendar files and flags
+const DateLayout = "2006-01-02"
+
+// Event represents one or more consecutive days off
+type Event struct {
+	Name  string
+	Start time.Time // first day off
+	End   time.Time // last day off, inclusive
+}
+
+// Calendar holds the days off that reduce a team's availability
+type Calendar struct {
+	// Holidays apply to the whole team
+	Holidays []Event
+	// PTO holds personal time off keyed by member name
+	PTO map[string][]Event
+}
+
+// Holiday represents a team-wide day off that falls on a working day of the sprint
+type Holiday struct {
+	Date time.Time `json:"date" yaml:"date"`
+	Name string    `json:"name,omitempty" yaml:"name,omitempty"`
+}
+
+// MemberAvailability represents the working days left for a member after holidays and PTO
+type MemberAvailability struct {
+	Name    string `json:"name" yaml:"name"`
+	PTODays int    `json:"pto_days" yaml:"pto_days"`
+	Days    int    `json:"days" yaml:"days"`
+}
+
+// Availability represents the working days in a sprint for each member
+type Availability struct {
+	Start       time.Time            `json:"start" yaml:"start"`
+	End         time.Time            `json:"end" yaml:"end"`
+	WeekDays    int                  `json:"week_days" yaml:"week_days"`
+	Holidays    []Holiday            `json:"holidays" yaml:"holidays"`
+	WorkingDays int                  `json:"working_days" yaml:"working_days"`
+	Members     []MemberAvailability `json:"members" yaml:"members"`
+}
+
+// ParseDate parses a YYYY-MM-DD date
+func ParseDate(s string) (time.Time, error) {
+	date, err := time.Parse(DateLayout, strings.TrimSpace(s))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", s)
+	}
+
+	return date, nil
+}
+
+// Load reads a calendar from an iCalendar (.ics) file or a YAML holiday and PTO list
+func Load(filename string) (*Calendar, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("reading calendar: %w", err)
+	}
+
+	var cal *Calendar
+	if strings.EqualFold(filepath.Ext(filename), ".ics") {
+		cal, err = ParseICS(data)
+	} else {
+		cal, err = ParseYAML(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loading calendar %s: %w", filename, err)
+	}
+
+	return cal, nil
+}
+
+// entry is a single date, or a {date|from,to,name} mapping, in a YAML calendar
+type entry struct {
+	Event
+}
+
+// UnmarshalYAML accepts either a bare date or a mapping with a date or a date range
+func (e *entry) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		date, err := ParseDate(node.Value)
+		if err != nil {
+			return err
+		}
+		e.Start, e.End = date, date
+		return nil
+	}
+
+	var raw struct {
+		Name string `yaml:"name"`
+		Date string `yaml:"date"`
+		From string `yaml:"from"`
+		To   string `yaml:"to"`
+	}
+	if err := node.Decode(&raw); err != nil {
+		return err
+	}
+
+	e.Name = raw.Name
+	switch {
+	case raw.Date != "" && raw.From == "" && raw.To == "":
+		date, err := ParseDate(raw.Date)
+		if err != nil {
+			return err
+		}
+		e.Start, e.End = date, date
+	case raw.Date == "" && raw.From != "" && raw.To != "":
+		from, err := ParseDate(raw.From)
+		if err != nil {
+			return err
+		}
+		to, err := ParseDate(raw.To)
+		if err != nil {
+			return err
+		}
+		if to.Before(from) {
+			return fmt.Errorf("range %s to %s ends before it starts", raw.From, raw.To)
+		}
+		e.Start, e.End = from, to
+	default:
+		return fmt.Errorf("line %d: set either date or from and to", node.Line)
+	}
+
+	return nil
+}
+
+// ParseYAML parses a calendar of the form
+//
+//	holidays:
+//	  - 2026-10-12
+//	  - {date: 2026-11-03, name: Culture Day}
+//	pto:
+//	  Alice:
+//	    - {from: 2026-10-20, to: 2026-10-21}
+func ParseYAML(data []byte) (*Calendar, error) {
+	var raw struct {
+		Holidays []entry            `yaml:"holidays"`
+		PTO      map[string][]entry `yaml:"pto"`
+	}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parsing YAML: %w", err)
+	}
+
+	cal := &Calendar{PTO: make(map[string][]Event, len(raw.PTO))}
+	for _, e := range raw.Holidays {
+		cal.Holidays = append(cal.Holidays, e.Event)
+	}
+	for member, entries := range raw.PTO {
+		for _, e := range entries {
+			cal.PTO[member] = append(cal.PTO[member], e.Event)
+		}
+	}
+
+	return cal, nil
+}
+
+// Availability counts the working days between start and end (inclusive) for each member:
+// weekdays minus team holidays minus the member's PTO
+func (c *Calendar) Availability(start, end time.Time, members []string) (Availability, error) {
+	if end.Before(start) {
+		return Availability{}, fmt.Errorf("sprint end %s is before its start %s", end.Format(DateLayout), start.Format(DateLayout))
+	}
+
+	for member := range c.PTO {
+		if !containsFold(members, member) {
+			return Availability{}, fmt.Errorf("PTO for %q who is not a team member", member)
+		}
+	}
+
+	result := Availability{Start: start, End: end, Holidays: []Holiday{}}
+	holidays := make(map[time.Time]bool)
+
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		if !isWeekday(day) {
+			continue
+		}
+		result.WeekDays++
+
+		if event, ok := find(c.Holidays, day); ok {
+			holidays[day] = true
+			result.Holidays = append(result.Holidays, Holiday{Date: day, Name: event.Name})
+		}
+	}
+	result.WorkingDays = result.WeekDays - len(result.Holidays)
+
+	for _, member := range members {
+		pto := 0
+		for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+			if isWeekday(day) && !holidays[day] {
+				if _, ok := find(c.ptoFor(member), day); ok {
+					pto++
+				}
+			}
+		}
+
+		result.Members = append(result.Members, MemberAvailability{
+			Name:    member,
+			PTODays: pto,
+			Days:    result.WorkingDays - pto,
+		})
+	}
+
+	return result, nil
+}
+
+// ApplyToTeam sets the available days of every member from the availability. Members with
+// explicit days keep them as their usual days in a sprint, less their holidays and PTO.
+func ApplyToTeam(team models.Team, availability Availability) models.Team {
+	members := make([]models.Member, len(team.Members))
+	for i, member := range team.Members {
+		for _, a := range availability.Members {
+			if a.Name != member.Name {
+				continue
+			}
+
+			days := float64(a.Days)
+			if member.Days != nil {
+				daysOff := float64(availability.WeekDays - a.Days)
+				days = max(*member.Days-daysOff, 0)
+			}
+			member.Days = &days
+		}
+		members[i] = member
+	}
+
+	team.Members = members
+	team.SprintDays = float64(availability.WorkingDays)
+
+	return team
+}
+
+// ptoFor returns the PTO of member, matching names case-insensitively
+func (c *Calendar) ptoFor(member string) []Event {
+	var events []Event
+	for name, pto := range c.PTO {
+		if strings.EqualFold(name, member) {
+			events = append(events, pto...)
+		}
+	}
+
+	return events
+}
+
+// find returns the first event covering day
+func find(events []Event, day time.Time) (Event, bool) {
+	for _, event := range events {
+		if !day.Before(event.Start) && !day.After(event.End) {
+			return event, true
+		}
+	}
+
+	return Event{}, false
+}
+
+func isWeekday(day time.Time) bool {
+	return day.Weekday() != time.Saturday && day.Weekday() != time.Sunday
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sortEvents orders events by start date
+func sortEvents(events []Event) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Start.Before(events[j].Start)
+	})
+}
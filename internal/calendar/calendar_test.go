@@ -0,0 +1,152 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gr1m0h/sizely/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(t *testing.T, s string) time.Time {
+	t.Helper()
+	d, err := ParseDate(s)
+	require.NoError(t, err)
+	return d
+}
+
+func TestParseYAML(t *testing.T) {
+	cal, err := ParseYAML([]byte(`
+holidays:
+  - 2026-10-12
+  - {date: 2026-11-03, name: Culture Day}
+pto:
+  Alice:
+    - 2026-10-14
+    - {from: 2026-10-20, to: 2026-10-22, name: Conference}
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, []Event{
+		{Start: date(t, "2026-10-12"), End: date(t, "2026-10-12")},
+		{Name: "Culture Day", Start: date(t, "2026-11-03"), End: date(t, "2026-11-03")},
+	}, cal.Holidays)
+	assert.Equal(t, []Event{
+		{Start: date(t, "2026-10-14"), End: date(t, "2026-10-14")},
+		{Name: "Conference", Start: date(t, "2026-10-20"), End: date(t, "2026-10-22")},
+	}, cal.PTO["Alice"])
+}
+
+func TestParseYAMLErrors(t *testing.T) {
+	for name, data := range map[string]string{
+		"Invalid date":   "holidays:\n  - 12/10/2026\n",
+		"Reversed range": "holidays:\n  - {from: 2026-10-20, to: 2026-10-10}\n",
+		"Ambiguous":      "holidays:\n  - {date: 2026-10-20, from: 2026-10-20, to: 2026-10-21}\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseYAML([]byte(data))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseICS(t *testing.T) {
+	data := "BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"SUMMARY:Sports Day\r\n" +
+		"DTSTART;VALUE=DATE:20261012\r\n" +
+		"DTEND;VALUE=DATE:20261013\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"SUMMARY:Conference\\, Berlin\r\n" +
+		"DTSTART:20261020T000000Z\r\n" +
+		"DTEND:20261023T000000Z\r\n" +
+		"ATTENDEE;CN=Alice;ROLE=REQ-PARTICIPANT:mailto:alice@example.com\r\n" +
+		"ATTENDEE:mailto:Bob@example.com\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"SUMMARY:Offsite with a very long summary that is fol\r\n" +
+		" ded\r\n" +
+		"DTSTART;VALUE=DATE:20261030\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	cal, err := ParseICS([]byte(data))
+	require.NoError(t, err)
+
+	assert.Equal(t, []Event{
+		{Name: "Sports Day", Start: date(t, "2026-10-12"), End: date(t, "2026-10-12")},
+		{Name: "Offsite with a very long summary that is folded", Start: date(t, "2026-10-30"), End: date(t, "2026-10-30")},
+	}, cal.Holidays)
+
+	conference := Event{Name: "Conference, Berlin", Start: date(t, "2026-10-20"), End: date(t, "2026-10-22")}
+	assert.Equal(t, []Event{conference}, cal.PTO["Alice"])
+	assert.Equal(t, []Event{conference}, cal.PTO["bob@example.com"])
+
+	_, err = ParseICS([]byte("BEGIN:VEVENT\nSUMMARY:Broken\n"))
+	assert.ErrorContains(t, err, "unterminated")
+
+	_, err = ParseICS([]byte("BEGIN:VEVENT\nDTSTART:tomorrow\nEND:VEVENT\n"))
+	assert.ErrorContains(t, err, "DTSTART")
+
+	// Only the first occurrence of a recurring event would otherwise count
+	_, err = ParseICS([]byte("BEGIN:VEVENT\nSUMMARY:Team day\nDTSTART;VALUE=DATE:20261002\nRRULE:FREQ=WEEKLY;COUNT=4\nEND:VEVENT\n"))
+	assert.ErrorContains(t, err, `event "Team day" repeats with RRULE, which is not supported`)
+
+	_, err = ParseICS([]byte("BEGIN:VEVENT\nDTSTART;VALUE=DATE:20261002\nRDATE;VALUE=DATE:20261009\nEND:VEVENT\n"))
+	assert.ErrorContains(t, err, "repeats with RDATE")
+}
+
+func TestAvailability(t *testing.T) {
+	cal := &Calendar{
+		Holidays: []Event{{Name: "Sports Day", Start: date(t, "2026-10-12"), End: date(t, "2026-10-12")}},
+		PTO: map[string][]Event{
+			// The weekend and the holiday inside the range do not count twice
+			"alice": {{Start: date(t, "2026-10-09"), End: date(t, "2026-10-13")}},
+		},
+	}
+
+	// Monday 2026-10-05 to Friday 2026-10-16: two weeks
+	result, err := cal.Availability(date(t, "2026-10-05"), date(t, "2026-10-16"), []string{"Alice", "Bob"})
+	require.NoError(t, err)
+
+	assert.Equal(t, 10, result.WeekDays)
+	assert.Equal(t, []Holiday{{Date: date(t, "2026-10-12"), Name: "Sports Day"}}, result.Holidays)
+	assert.Equal(t, 9, result.WorkingDays)
+	assert.Equal(t, []MemberAvailability{
+		{Name: "Alice", PTODays: 2, Days: 7},
+		{Name: "Bob", PTODays: 0, Days: 9},
+	}, result.Members)
+
+	_, err = cal.Availability(date(t, "2026-10-16"), date(t, "2026-10-05"), nil)
+	assert.ErrorContains(t, err, "before its start")
+
+	_, err = cal.Availability(date(t, "2026-10-05"), date(t, "2026-10-16"), []string{"Bob"})
+	assert.ErrorContains(t, err, `PTO for "alice"`)
+}
+
+func TestApplyToTeam(t *testing.T) {
+	bob, carol := 3.0, 1.0
+	team := models.Team{
+		PointsPerDay: 2,
+		SprintDays:   10,
+		Members:      []models.Member{{Name: "Alice"}, {Name: "Bob", Days: &bob}, {Name: "Carol", Days: &carol}},
+	}
+
+	// One holiday for everyone, and two days of PTO for Alice and Carol
+	applied := ApplyToTeam(team, Availability{
+		WeekDays:    10,
+		WorkingDays: 9,
+		Members:     []MemberAvailability{{Name: "Alice", Days: 7}, {Name: "Bob", Days: 9}, {Name: "Carol", Days: 7}},
+	})
+
+	require.NotNil(t, applied.Members[0].Days)
+	assert.Equal(t, 7.0, *applied.Members[0].Days)
+	assert.Equal(t, 2.0, *applied.Members[1].Days, "explicit days lose the holiday")
+	assert.Equal(t, 0.0, *applied.Members[2].Days, "days off beyond the explicit days leave none")
+	assert.Equal(t, 9.0, applied.SprintDays)
+	assert.Nil(t, team.Members[0].Days, "the original team is not modified")
+	assert.Equal(t, 3.0, bob, "the original explicit days are not modified")
+}
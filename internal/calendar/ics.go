@@ -0,0 +1,171 @@
+package calendar
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ParseICS parses the VEVENTs of an iCalendar file. Events with attendees are PTO for each
+// attendee (named by the CN parameter, falling back to the address); events without
+// attendees are team holidays. Recurring events are rejected rather than read as their first
+// occurrence only.
+func ParseICS(data []byte) (*Calendar, error) {
+	cal := &Calendar{PTO: make(map[string][]Event)}
+
+	var (
+		inEvent   bool
+		event     Event
+		attendees []string
+		endValue  icsValue
+		repeats   string
+	)
+
+	for i, line := range unfold(data) {
+		name, value := splitProperty(line)
+
+		switch {
+		case name.name == "BEGIN" && value == "VEVENT":
+			inEvent, event, attendees, endValue, repeats = true, Event{}, nil, icsValue{}, ""
+		case name.name == "END" && value == "VEVENT":
+			if !inEvent {
+				return nil, fmt.Errorf("line %d: END:VEVENT without BEGIN:VEVENT", i+1)
+			}
+			inEvent = false
+
+			if event.Start.IsZero() {
+				return nil, fmt.Errorf("event %q has no DTSTART", event.Name)
+			}
+			if repeats != "" {
+				return nil, fmt.Errorf("event %q repeats with %s, which is not supported; list its occurrences as separate events", event.Name, repeats)
+			}
+			event.End = endValue.lastDay(event.Start)
+
+			if len(attendees) == 0 {
+				cal.Holidays = append(cal.Holidays, event)
+			}
+			for _, attendee := range attendees {
+				cal.PTO[attendee] = append(cal.PTO[attendee], event)
+			}
+		case !inEvent:
+			continue
+		case name.name == "SUMMARY":
+			event.Name = unescape(value)
+		case name.name == "DTSTART":
+			start, err := parseICSValue(name, value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: DTSTART: %w", i+1, err)
+			}
+			event.Start = start.date
+		case name.name == "DTEND":
+			end, err := parseICSValue(name, value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: DTEND: %w", i+1, err)
+			}
+			endValue = end
+		case name.name == "RRULE", name.name == "RDATE":
+			repeats = name.name
+		case name.name == "ATTENDEE":
+			attendee := name.params["CN"]
+			if attendee == "" {
+				attendee = strings.TrimPrefix(strings.ToLower(value), "mailto:")
+			}
+			attendees = append(attendees, attendee)
+		}
+	}
+
+	if inEvent {
+		return nil, fmt.Errorf("unterminated VEVENT")
+	}
+
+	sortEvents(cal.Holidays)
+
+	return cal, nil
+}
+
+// icsProperty is a property name with its parameters, e.g. DTSTART;VALUE=DATE
+type icsProperty struct {
+	name   string
+	params map[string]string
+}
+
+// icsValue is a parsed DTSTART or DTEND
+type icsValue struct {
+	date     time.Time
+	dateOnly bool
+	midnight bool
+	set      bool
+}
+
+// lastDay returns the last day covered by an event starting on start and ending at v.
+// Date-only and midnight ends are exclusive, as in RFC 5545; a missing end means a single day.
+func (v icsValue) lastDay(start time.Time) time.Time {
+	if !v.set {
+		return start
+	}
+
+	end := v.date
+	if (v.dateOnly || v.midnight) && end.After(start) {
+		end = end.AddDate(0, 0, -1)
+	}
+
+	return end
+}
+
+// unfold joins folded iCalendar lines, which continue with a leading space or tab
+func unfold(data []byte) []string {
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// splitProperty splits "NAME;PARAM=VALUE:value" into the property and its value
+func splitProperty(line string) (icsProperty, string) {
+	head, value, _ := strings.Cut(line, ":")
+	parts := strings.Split(head, ";")
+
+	prop := icsProperty{name: strings.ToUpper(parts[0]), params: make(map[string]string)}
+	for _, param := range parts[1:] {
+		key, val, _ := strings.Cut(param, "=")
+		prop.params[strings.ToUpper(key)] = strings.Trim(val, `"`)
+	}
+
+	return prop, value
+}
+
+// parseICSValue parses a DATE (20261012) or DATE-TIME (20261012T090000Z) value
+func parseICSValue(prop icsProperty, value string) (icsValue, error) {
+	value = strings.TrimSpace(value)
+
+	if prop.params["VALUE"] == "DATE" || len(value) == len("20060102") {
+		date, err := time.Parse("20060102", value)
+		if err != nil {
+			return icsValue{}, fmt.Errorf("invalid date %q", value)
+		}
+		return icsValue{date: date, dateOnly: true, set: true}, nil
+	}
+
+	t, err := time.Parse("20060102T150405", strings.TrimSuffix(value, "Z"))
+	if err != nil {
+		return icsValue{}, fmt.Errorf("invalid date-time %q", value)
+	}
+
+	date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return icsValue{date: date, midnight: t.Equal(date), set: true}, nil
+}
+
+// unescape reverses iCalendar text escaping
+func unescape(s string) string {
+	return strings.NewReplacer(`\,`, ",", `\;`, ";", `\n`, " ", `\N`, " ", `\\`, `\`).Replace(s)
+}
@@ -1,72 +1,511 @@
 package cli
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"io"
+	"math/rand"
+	"net"
 	"os"
+	"strings"
+	"time"
 
-	"github.com/gr1m0h/sizely/internal/calculator"
+	"github.com/gr1m0h/sizely/internal/calendar"
+	"github.com/gr1m0h/sizely/internal/config"
+	"github.com/gr1m0h/sizely/internal/history"
+	"github.com/gr1m0h/sizely/internal/input"
+	"github.com/gr1m0h/sizely/internal/mcp"
 	"github.com/gr1m0h/sizely/internal/models"
+	"github.com/gr1m0h/sizely/internal/render"
+	"github.com/gr1m0h/sizely/internal/rpc"
+	"github.com/gr1m0h/sizely/internal/server"
+	"github.com/gr1m0h/sizely/pkg/sizely"
 )
 
-// App represents the CLI application
+// App represents the CLI application, a thin layer reading documents and files for the
+// estimator of the sizely package
 type App struct {
-	calculator *calculator.Calculator
-	output     *OutputFormatter
+	config    *config.Config
+	estimator *sizely.Estimator
+	style     render.Style
+	team      *models.Team
+	sprint    *sprintCalendar
+	forecast  *forecastOptions
+
+	template    string
+	constraints models.Constraints
+}
+
+// forecastOptions configures the Monte Carlo forecast printed with the sprint capacity
+type forecastOptions struct {
+	trials int
+	rng    *rand.Rand
+}
+
+// sprintCalendar holds the sprint dates and days off used to derive team availability
+type sprintCalendar struct {
+	start    time.Time
+	end      time.Time
+	calendar *calendar.Calendar
+}
+
+// NewApp creates a new CLI application instance from the effective configuration,
+// rendering results to standard output in the configured format
+func NewApp(cfg *config.Config) (*App, error) {
+	app := &App{
+		config: cfg,
+		style:  TerminalStyle(os.Stdout, cfg, os.Getenv),
+	}
+
+	if err := app.rebuild(); err != nil {
+		return nil, err
+	}
+
+	return app, nil
 }
 
-// NewApp creates a new CLI application instance
-func NewApp() *App {
-	return &App{
-		calculator: calculator.NewCalculator(),
-		output:     NewOutputFormatter(),
+// options returns the estimator options of the configuration, writing to standard output
+func (a *App) options() []sizely.Option {
+	return []sizely.Option{
+		sizely.WithScale(a.config.Scale),
+		sizely.WithMaxTasks(a.config.MaxTasks),
+		sizely.WithOutput(os.Stdout, a.config.Format),
+		sizely.WithTemplate(a.template),
+		sizely.WithStyle(a.style),
+		sizely.WithAdvice(a.config.Advice),
+		sizely.WithConstraints(a.constraints),
 	}
 }
 
-// CalculateFromFile calculates capacity from a JSON file
-func (a *App) CalculateFromFile(filename string) error {
+// rebuild replaces the estimator after a change of the options
+func (a *App) rebuild() error {
+	estimator, err := sizely.New(a.options()...)
+	if err != nil {
+		return err
+	}
+	a.estimator = estimator
+
+	return nil
+}
+
+// UseTemplate renders the results with a text/template instead of the configured format; spec is
+// a template file, or the template text itself when it contains an action
+func (a *App) UseTemplate(spec string) error {
+	text := spec
+	if !strings.Contains(spec, "{{") {
+		data, err := os.ReadFile(spec)
+		if err != nil {
+			return fmt.Errorf("reading template: %w", err)
+		}
+		text = string(data)
+	}
+	a.template = text
+
+	return a.rebuild()
+}
+
+// Constrain limits the tasks of each size in the combinations of ReverseCalculate
+func (a *App) Constrain(constraints models.Constraints) error {
+	a.constraints = constraints
+
+	return a.rebuild()
+}
+
+// CalculateFromFile calculates capacity from a JSON or YAML file; an auto format is resolved from the file extension
+func (a *App) CalculateFromFile(filename string, format input.Format) error {
 	data, err := os.ReadFile(filename)
 	if err != nil {
 		return fmt.Errorf("reading file: %w", err)
 	}
 
-	return a.CalculateFromJSON(string(data))
+	return a.CalculateFromData(data, input.FormatForFile(filename, format))
+}
+
+// CalculateFromReader calculates capacity from a JSON or YAML document read from r, such as standard input
+func (a *App) CalculateFromReader(r io.Reader, format input.Format) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("reading input: %w", err)
+	}
+
+	return a.CalculateFromData(data, format)
+}
+
+// CalculateFromData calculates capacity from a JSON or YAML document holding either
+// task counts per size or an itemized backlog list
+func (a *App) CalculateFromData(data []byte, format input.Format) error {
+	capacity, err := a.decodeCapacity(data, format)
+	if err != nil {
+		return err
+	}
+
+	return a.printCapacity(capacity)
 }
 
-// CalculateFromJSON calculates capacity from a JSON string
-func (a *App) CalculateFromJSON(jsonStr string) error {
+// decodeCapacity calculates capacity from task counts per size or an itemized backlog list
+func (a *App) decodeCapacity(data []byte, format input.Format) (models.SprintCapacity, error) {
+	isList, err := input.IsList(data, format)
+	if err != nil {
+		return models.SprintCapacity{}, err
+	}
+
+	if isList {
+		var items []models.BacklogItem
+		if err := input.Decode(data, format, &items); err != nil {
+			return models.SprintCapacity{}, err
+		}
+
+		return a.estimator.BacklogCapacity(context.Background(), items)
+	}
+
 	var tasks models.TaskCount
-	if err := json.Unmarshal([]byte(jsonStr), &tasks); err != nil {
-		return fmt.Errorf("parsing JSON: %w", err)
+	if err := input.Decode(data, format, &tasks); err != nil {
+		return models.SprintCapacity{}, err
+	}
+
+	return a.estimator.Capacity(context.Background(), tasks)
+}
+
+// printCapacity prints the sprint capacity and, when a team is loaded or a forecast requested,
+// how it compares with the team's capacity and how likely it is to land
+func (a *App) printCapacity(capacity models.SprintCapacity) error {
+	report := render.CapacityReport{Capacity: capacity}
+
+	if a.team != nil {
+		if err := a.compareWithTeam(&report); err != nil {
+			return err
+		}
+	}
+
+	if a.forecast != nil {
+		if err := a.forecastCapacity(&report); err != nil {
+			return err
+		}
+	}
+
+	return a.estimator.WriteCapacity(report)
+}
+
+// compareWithTeam adds the team's availability during the sprint and its capacity to the report
+func (a *App) compareWithTeam(report *render.CapacityReport) error {
+	team := *a.team
+	if a.sprint != nil {
+		names := make([]string, len(team.Members))
+		for i, member := range team.Members {
+			names[i] = member.Name
+		}
+
+		availability, err := a.sprint.calendar.Availability(a.sprint.start, a.sprint.end, names)
+		if err != nil {
+			return err
+		}
+
+		report.Availability = &availability
+		team = calendar.ApplyToTeam(team, availability)
+	}
+
+	teamCapacity, err := a.estimator.TeamCapacity(context.Background(), team, report.Capacity.TotalPoints)
+	if err != nil {
+		return err
+	}
+
+	report.Team = &teamCapacity
+
+	return nil
+}
+
+// forecastCapacity adds the simulated probability of completing the planned points, based on the velocity history
+func (a *App) forecastCapacity(report *render.CapacityReport) error {
+	records, err := history.NewStore(a.config.HistoryFile).Load()
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return fmt.Errorf("forecasting needs velocity history; no sprints recorded in %s", a.config.HistoryFile)
+	}
+
+	forecast, err := a.estimator.ForecastSprint(context.Background(), records, report.Capacity.TotalPoints, a.forecast.trials, a.forecast.rng)
+	if err != nil {
+		return err
+	}
+
+	report.Forecast = &forecast
+
+	return nil
+}
+
+// ReleaseOptions configures a backlog release forecast
+type ReleaseOptions struct {
+	Start        string // first day of the first forecast sprint (YYYY-MM-DD); empty continues the history or starts today
+	SprintLength int    // sprint length in calendar days
+	Trials       int    // number of simulated releases
+	Seed         int64  // random seed; 0 picks one
+}
+
+// ForecastRelease predicts how many sprints, and until which dates, it takes to complete a backlog
+// document holding task counts per size or an itemized backlog list
+func (a *App) ForecastRelease(data []byte, format input.Format, opts ReleaseOptions) error {
+	capacity, err := a.decodeCapacity(data, format)
+	if err != nil {
+		return err
+	}
+
+	records, err := history.NewStore(a.config.HistoryFile).Load()
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return fmt.Errorf("forecasting needs velocity history; no sprints recorded in %s", a.config.HistoryFile)
+	}
+
+	start, err := releaseStart(opts.Start, records, opts.SprintLength)
+	if err != nil {
+		return err
+	}
+
+	seed := opts.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	forecast, err := a.estimator.ForecastRelease(context.Background(), records, capacity.Tasks, start, opts.SprintLength, opts.Trials, rand.New(rand.NewSource(seed)))
+	if err != nil {
+		return err
+	}
+
+	return a.estimator.WriteRelease(forecast)
+}
+
+// releaseStart returns the given start date or, without one, the sprint after the last recorded
+// sprint with a start date, falling back to today
+func releaseStart(start string, records []models.SprintRecord, sprintLength int) (time.Time, error) {
+	if start != "" {
+		return calendar.ParseDate(start)
+	}
+
+	for i := len(records) - 1; i >= 0; i-- {
+		if records[i].Start == "" {
+			continue
+		}
+
+		last, err := calendar.ParseDate(records[i].Start)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("sprint %s: %w", records[i].Sprint, err)
+		}
+
+		return last.AddDate(0, 0, (len(records)-i)*sprintLength), nil
+	}
+
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC), nil
+}
+
+// EnableForecast adds a Monte Carlo forecast of trials simulated sprints to the capacity output;
+// a seed of 0 picks a random seed
+func (a *App) EnableForecast(trials int, seed int64) error {
+	if trials <= 0 {
+		return fmt.Errorf("trials must be positive")
+	}
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	a.forecast = &forecastOptions{trials: trials, rng: rand.New(rand.NewSource(seed))}
+
+	return nil
+}
+
+// LoadTeam reads the team definition used to compare plans with the team's capacity
+func (a *App) LoadTeam(filename string) error {
+	data, format, err := input.ReadFile(filename, input.FormatAuto)
+	if err != nil {
+		return err
 	}
 
-	capacity := a.calculator.CalculateSprintCapacity(tasks)
-	a.output.PrintCapacity(capacity)
+	var team models.Team
+	if err := input.Decode(data, format, &team); err != nil {
+		return fmt.Errorf("loading team %s: %w", filename, err)
+	}
+
+	a.team = &team
+
+	return nil
+}
+
+// SetSprintCalendar derives each team member's available days from the sprint dates (YYYY-MM-DD),
+// excluding weekends and the holidays and PTO in the optional calendar file (.ics or YAML)
+func (a *App) SetSprintCalendar(start, end, filename string) error {
+	if a.team == nil {
+		return fmt.Errorf("sprint dates and calendars require a team file")
+	}
+
+	sprint := &sprintCalendar{calendar: &calendar.Calendar{}}
+
+	var err error
+	if sprint.start, err = calendar.ParseDate(start); err != nil {
+		return fmt.Errorf("sprint start: %w", err)
+	}
+	if sprint.end, err = calendar.ParseDate(end); err != nil {
+		return fmt.Errorf("sprint end: %w", err)
+	}
+
+	if filename != "" {
+		if sprint.calendar, err = calendar.Load(filename); err != nil {
+			return err
+		}
+	}
+
+	a.sprint = sprint
+
+	return nil
+}
+
+// ReverseCalculate finds all combinations of at most the configured max tasks for given points
+func (a *App) ReverseCalculate(points int) error {
+	result, err := a.estimator.Combinations(context.Background(), points)
+	if err != nil {
+		return err
+	}
+
+	return a.estimator.WriteCombinations(result)
+}
+
+// PlanBacklog selects at most the configured max tasks from a prioritized backlog document
+// that best fit the target points
+func (a *App) PlanBacklog(data []byte, format input.Format, points int) error {
+	var items []models.BacklogItem
+	if err := input.Decode(data, format, &items); err != nil {
+		return err
+	}
+
+	result, err := a.estimator.Plan(context.Background(), items, points)
+	if err != nil {
+		return err
+	}
+
+	return a.estimator.WritePlan(result)
+}
+
+// RecordSprint appends a finished sprint to the history file; planned and completed are
+// JSON or YAML documents holding task counts per size or an itemized backlog list
+func (a *App) RecordSprint(sprint, start, end string, planned, completed []byte) error {
+	for _, date := range []string{start, end} {
+		if date == "" {
+			continue
+		}
+		if _, err := calendar.ParseDate(date); err != nil {
+			return err
+		}
+	}
+
+	plannedCapacity, err := a.decodeCapacity(planned, input.FormatAuto)
+	if err != nil {
+		return fmt.Errorf("planned work: %w", err)
+	}
+	completedCapacity, err := a.decodeCapacity(completed, input.FormatAuto)
+	if err != nil {
+		return fmt.Errorf("completed work: %w", err)
+	}
+
+	record := models.SprintRecord{
+		Sprint:          sprint,
+		Start:           start,
+		End:             end,
+		Scale:           a.config.Scale.Name,
+		Planned:         plannedCapacity.Tasks,
+		Completed:       completedCapacity.Tasks,
+		PlannedPoints:   plannedCapacity.TotalPoints,
+		CompletedPoints: completedCapacity.TotalPoints,
+	}
+
+	store := history.NewStore(a.config.HistoryFile)
+	if err := store.Append(record); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.stdout(), "📝 Recorded sprint %s: %d of %d planned points completed (%s)\n",
+		record.Sprint, record.CompletedPoints, record.PlannedPoints, store.Path())
 
 	return nil
 }
 
-// ReverseCalculate finds all combinations for given points
-func (a *App) ReverseCalculate(points, maxTasks int, outputJSON bool) error {
-	if points <= 0 {
-		return fmt.Errorf("points must be positive")
+// ShowVelocity prints velocity statistics from the history file using a rolling window of recent sprints
+func (a *App) ShowVelocity(window int) error {
+	records, err := history.NewStore(a.config.HistoryFile).Load()
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return fmt.Errorf("no sprints recorded in %s; add one with: sizely velocity add", a.config.HistoryFile)
+	}
+
+	report, err := a.estimator.Velocity(context.Background(), records, window)
+	if err != nil {
+		return err
+	}
+
+	return a.estimator.WriteVelocity(report)
+}
+
+// ShowConfig prints the effective configuration with the source of each value
+func (a *App) ShowConfig() error {
+	renderer, err := render.New(a.config.Format, os.Stdout, a.config.Scale, a.config.Advice, a.style)
+	if err != nil {
+		return err
+	}
+
+	return renderer.Config(a.config)
+}
+
+// Serve runs the HTTP JSON API on addr with the configured scale and max tasks until ctx is canceled
+func (a *App) Serve(ctx context.Context, addr string) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listening: %w", err)
 	}
 
-	if maxTasks <= 0 {
-		return fmt.Errorf("max tasks must be positive")
+	fmt.Fprintf(os.Stderr, "sizely API listening on http://%s (scale %s)\n", l.Addr(), a.config.Scale)
+	if err := server.New(a.estimator).Serve(ctx, l); err != nil {
+		return err
 	}
+	fmt.Fprintln(os.Stderr, "sizely API stopped")
 
-	result := a.calculator.FindCombinations(points, maxTasks)
+	return nil
+}
+
+// ServeGRPC runs the gRPC service on addr with the configured scale and max tasks until ctx is canceled
+func (a *App) ServeGRPC(ctx context.Context, addr string) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listening: %w", err)
+	}
 
-	if outputJSON {
-		a.output.PrintCombinationsJSON(result)
-	} else {
-		a.output.PrintCombinations(result)
+	fmt.Fprintf(os.Stderr, "sizely gRPC service listening on %s (scale %s)\n", l.Addr(), a.config.Scale)
+	if err := rpc.New(a.estimator).Serve(ctx, l); err != nil {
+		return err
 	}
+	fmt.Fprintln(os.Stderr, "sizely gRPC service stopped")
 
 	return nil
 }
 
+// ServeMCP answers Model Context Protocol messages on standard input and output with the
+// configured scale and max tasks until the client disconnects or ctx is canceled
+func (a *App) ServeMCP(ctx context.Context) error {
+	fmt.Fprintf(os.Stderr, "sizely MCP server ready on stdio (scale %s)\n", a.config.Scale)
+
+	return mcp.New(a.estimator).Serve(ctx, os.Stdin, os.Stdout)
+}
+
+// stdout returns standard output for messages printed outside the renderer, in ASCII when configured
+func (a *App) stdout() io.Writer {
+	if a.style.ASCII {
+		return render.ASCIIWriter(os.Stdout)
+	}
+
+	return os.Stdout
+}
+
 // ShowHelp displays help information
 func ShowHelp() {
 	fmt.Println(`sizely - T-shirt size estimation and sprint capacity planning tool
@@ -81,16 +520,201 @@ USAGE:
 COMMANDS:
   points              Calculate total sprint points from T-shirt size counts (default)
   tasks               Find all possible task combinations for a target point value
+  plan                Select backlog tickets, in priority order, that best fit a target point value
+  forecast            Forecast the sprints and dates needed to complete a backlog
+  velocity            Show velocity statistics from the recorded sprint history
+  velocity add        Record a finished sprint's planned and completed work
+  config show         Show the effective configuration and where each value came from
+                      (accepts --format)
+  serve               Serve points and combinations as an HTTP JSON API
+  mcp                 Serve points, combinations and backlog planning as MCP tools over stdio
   help                Show this help information
 
 points OPTIONS:
-  -f, --file FILE     Path to JSON file containing T-shirt size task counts
-  -d, --data STRING   JSON string containing T-shirt size task counts
+  -f, --file FILE     Path to JSON or YAML file containing task counts or a backlog ("-" for stdin)
+  -d, --data STRING   JSON or YAML string containing task counts or a backlog
+  --input-format FMT  Input format: auto (default), json or yaml
+                      Without -f or -d, task counts are read from piped standard input
+  --team FILE         Team file (YAML or JSON) to compare the plan with the team's capacity
+  --sprint-start DATE First day of the sprint (YYYY-MM-DD); derives members' days from the calendar
+  --sprint-end DATE   Last day of the sprint (YYYY-MM-DD)
+  --calendar FILE     Holidays and PTO as an iCalendar (.ics) file or a YAML list
+  --forecast          Simulate the chance of completing the plan from the velocity history
+  --trials INT        Number of simulated sprints for --forecast (default: 10000)
+  --seed INT          Random seed to make --forecast reproducible
+  --history FILE      Velocity history file (default: history_file, .sizely/history.jsonl)
+  --format FMT        Output format: text (default), json, yaml, csv, markdown or html
+  --template TMPL     Go template file, or inline template text, to render the result with
+  --scale NAME        Built-in scale preset: tshirt (default), fibonacci, pow2, linear
+  --scale-file FILE   Path to a YAML or JSON size scale definition
 
 tasks OPTIONS:
   <points>            Target points for reverse calculation (required positional argument)
-  -c, --count INT     Maximum number of total tasks allowed in combinations (default: 15)
-  -o, --output-json   Output results in JSON format
+  -c, --count INT     Maximum number of total tasks allowed in combinations (default: max_tasks, 15)
+  --min SIZE=N        Require at least N tasks of SIZE; repeatable or comma-separated
+  --max SIZE=N        Allow at most N tasks of SIZE; repeatable or comma-separated
+  --exclude SIZE      Leave SIZE out of the combinations; repeatable or comma-separated
+  --format FMT        Output format: text (default), json, yaml, csv, markdown or html
+  -o, --output-json   Shorthand for --format json
+  --template TMPL     Go template file, or inline template text, to render the result with
+  --scale NAME        Built-in scale preset: tshirt (default), fibonacci, pow2, linear
+  --scale-file FILE   Path to a YAML or JSON size scale definition
+
+CONFIGURATION:
+  Settings are layered, later sources overriding earlier ones:
+    1. Built-in defaults
+    2. User config: $XDG_CONFIG_HOME/sizely/config.yaml (~/.config/sizely/config.yaml)
+    3. Project config: the nearest .sizely.yaml in the working directory or its parents
+    4. Environment: SIZELY_SCALE, SIZELY_SCALE_FILE, SIZELY_MAX_TASKS, SIZELY_FORMAT,
+                    SIZELY_HISTORY_FILE, SIZELY_ADVICE_*
+    5. Command-line flags
+
+  Example .sizely.yaml:
+    scale: fibonacci               # a preset name or an inline scale: {name: ..., sizes: [...]}
+    # scale_file: scales/team.yaml # or a scale definition file
+    max_tasks: 12
+    format: text                   # text, json, yaml, csv, markdown or html
+    color: auto                    # auto, always or never
+    ascii: false                   # plain ASCII instead of emoji and box-drawing characters
+    width: 0                       # text width in columns; 0 uses the terminal width
+    history_file: .sizely/history.jsonl # sprint history, relative to this file
+    advice:
+      low_task_count: 6            # at or below: focused work
+      high_task_count: 12          # at or above: context switching warning
+      heavy_large_count: 3         # largest-size tasks at or above: heavy on large tasks
+      many_small_count: 6          # smallest-size tasks at or above, one fewer per size up
+
+plan OPTIONS:
+  <points>            Target points for the sprint (required positional argument)
+  -f, --file FILE     Path to a JSON or YAML backlog list in priority order ("-" for stdin)
+                      Items are only selected together with their depends_on prerequisites
+  -c, --count INT     Maximum number of tasks to select (default: max_tasks, 15)
+  --input-format FMT  Input format: auto (default), json or yaml
+  --format FMT        Output format: text (default), json, yaml, csv, markdown or html
+  --scale NAME        Built-in scale preset: tshirt (default), fibonacci, pow2, linear
+  --scale-file FILE   Path to a YAML or JSON size scale definition
+
+forecast OPTIONS:
+  Uses the velocity history, which must be recorded on the current scale.
+  -f, --file FILE     Backlog task counts or itemized backlog, JSON or YAML ("-" for stdin)
+  -d, --data STRING   Backlog task counts or itemized backlog as a JSON or YAML string
+  --input-format FMT  Input format: auto (default), json or yaml
+  --start DATE        First day of the first forecast sprint (YYYY-MM-DD); defaults to the
+                      sprint after the last recorded one with a start date, or today
+  --sprint-length N   Sprint length in calendar days (default: 14)
+  --trials INT        Number of simulated releases (default: 10000)
+  --seed INT          Random seed to make the forecast reproducible
+  --format FMT        Output format: text (default), json, yaml, csv, markdown or html
+  -o, --output-json   Shorthand for --format json
+  --history FILE      Velocity history file (default: history_file, .sizely/history.jsonl)
+
+velocity OPTIONS:
+  The history must be recorded on the current scale; velocity, --forecast and
+  forecast refuse sprints recorded on another one.
+  -w, --window INT    Number of recent sprints for the rolling average (default: 3)
+  --format FMT        Output format: text (default), json, yaml, csv, markdown or html
+  --history FILE      Velocity history file (default: history_file, .sizely/history.jsonl)
+
+velocity add OPTIONS:
+  --sprint NAME       Sprint name (required, must be unique in the history)
+  --planned DOC       Planned task counts or backlog as a JSON or YAML string
+  --planned-file FILE File with the planned task counts or backlog
+  --completed DOC     Completed task counts or backlog as a JSON or YAML string
+  --completed-file F  File with the completed task counts or backlog
+  --start DATE        First day of the sprint (YYYY-MM-DD, optional)
+  --end DATE          Last day of the sprint (YYYY-MM-DD, optional)
+  --history FILE      Velocity history file (default: history_file, .sizely/history.jsonl)
+
+serve OPTIONS:
+  --addr ADDR         Address to listen on (default: localhost:8080, or localhost:50051 with --grpc)
+  --grpc              Serve the gRPC service (api/sizely/v1/sizely.proto) instead of the HTTP API
+  -c, --count INT     Default max_tasks for combinations (default: max_tasks, 15)
+  --scale NAME        Built-in scale preset: tshirt (default), fibonacci, pow2, linear
+  --scale-file FILE   Path to a YAML or JSON size scale definition
+  Endpoints:
+    POST /v1/points                            Task counts in, sprint capacity out
+    GET  /v1/combinations?points=N&max_tasks=M Combinations for N points (N up to 1000, max_tasks up
+                                               to 100, at most 10000 combinations)
+    GET  /openapi.json                         OpenAPI document of the API
+    GET  /healthz                              Health check
+  Errors are JSON objects with an "error" message. SIGINT or SIGTERM stops the server
+  after in-flight requests finish.
+  With --grpc, sizely.v1.SizelyService offers CalculatePoints, CalculateSprintCapacity,
+  FindCombinations and the server-streaming StreamCombinations, with server reflection.
+
+mcp OPTIONS:
+  -c, --count INT     Default max_tasks of the tools (default: max_tasks, 15)
+  --scale NAME        Built-in scale preset: tshirt (default), fibonacci, pow2, linear
+  --scale-file FILE   Path to a YAML or JSON size scale definition
+  Speaks the Model Context Protocol on stdin and stdout, for AI assistants to start as a
+  local server. Tools:
+    calculate_points   Task counts per size in, sprint capacity out
+    find_combinations  Combinations of sizes for a point target (points up to 1000, max_tasks
+                       up to 100, at most 10000 combinations)
+    plan_backlog       Up to 200 prioritized backlog items in, selected and deferred items out
+
+OUTPUT FORMATS:
+  --format (or format in the configuration) selects how results are written to stdout:
+    text      Human-readable tables (default)
+    json      A single JSON document, e.g. for jq
+    yaml      The same document as YAML
+    csv       The main table with a header row, for spreadsheets
+    markdown  Headings and tables, for wiki pages and pull requests
+    html      A self-contained page with inline SVG charts, for sharing with stakeholders
+  Errors and diagnostics are written to stderr.
+
+TERMINAL OUTPUT:
+  Every command printing text accepts these flags (or color, ascii and width in the configuration):
+  --color MODE        auto (default) colors charts and advice on a terminal unless NO_COLOR is set;
+                      always or never force it
+  --no-color          Same as --color never
+  --ascii             Replace emoji and box-drawing characters with plain ASCII, e.g. for CI logs
+  --width INT         Width in columns; by default the terminal width, else COLUMNS, else 80
+
+TEMPLATES:
+  --template on points and tasks executes a Go text/template instead of a fixed format.
+  The value is a template file, or the template itself when it contains "{{".
+  points templates see the sprint capacity (.TotalPoints, .TotalTasks, .Breakdown, .Tasks,
+  .Effort) plus .Team, .Availability and .Forecast; tasks templates see .TargetPoints,
+  .MaxTasks, .Combinations and .TotalFound. Helpers:
+    pad N S, padLeft N S   Pad S with spaces to N characters
+    sizes, descending      The sizes of the scale, smallest or largest first
+    percent A B, ratio F   A as a percentage of B; a 0-1 ratio as a percentage
+    add A B, mul A B       Integer arithmetic
+    label C, advice C      A combination as "L×1 + S×1"; its advice lines
+    join SEP L, repeat N S, upper S, lower S
+
+TEAM FILE FORMAT:
+  name: Platform
+  points_per_day: 2.5   # points historically delivered per focused person-day
+  focus_factor: 0.7     # share of available time spent on sprint work (default: 1.0)
+  sprint_days: 10       # available days for members without their own days
+  members:
+    - name: Alice
+    - name: Bob
+      days: 6           # part-time or on leave
+      focus_factor: 0.5
+
+  Capacity is the sum of days × focus factor × points per day. Plans above the
+  capacity are flagged as over-committed, plans below 80% as under-committed.
+
+  With --sprint-start and --sprint-end, members get the weekdays of the sprint
+  minus holidays and their PTO from --calendar; members with explicit days get
+  their holidays and PTO subtracted from those days.
+
+CALENDAR FILE FORMAT:
+  YAML:
+    holidays:
+      - 2026-10-12
+      - {date: 2026-11-03, name: Culture Day}
+    pto:
+      Alice:
+        - 2026-10-14
+        - {from: 2026-10-20, to: 2026-10-22}
+
+  iCalendar (.ics): all-day or timed VEVENTs; events with ATTENDEEs are PTO for
+  each attendee (matched by CN), events without attendees are team holidays.
+  Recurring events (RRULE, RDATE) are not supported; list each occurrence.
 
 T-SHIRT SIZE POINT SYSTEM:
   XS: 1 point   (30 minutes - 4 hours)
@@ -98,6 +722,26 @@ T-SHIRT SIZE POINT SYSTEM:
   M:  5 points  (2-3 days)
   L:  10 points (1 week)
 
+  points reports the total effort of these time ranges as min / expected / max
+  hours, where expected is the PERT mean (min + 4 × likely + max) / 6.
+
+  Built-in presets (--scale NAME) share the size names, so the same task counts
+  can be compared across conventions:
+    tshirt     XS=1, S=3, M=5, L=10
+    fibonacci  XS=1, S=2, M=3, L=5, XL=8, XXL=13, XXXL=21
+    pow2       XS=1, S=2, M=4, L=8, XL=16, XXL=32
+    linear     XS=1, S=2, M=3, L=4, XL=5
+
+  Use --scale-file to define your own sizes, optionally with hours per size, for example:
+    name: extended
+    sizes:
+      - {name: XS, points: 1, hours: {min: 0.5, likely: 1, max: 2}}
+      - {name: S, points: 2, hours: {min: 2, likely: 4, max: 8}}
+      - {name: M, points: 3, hours: {min: 8, likely: 12, max: 16}}
+      - {name: L, points: 5, hours: {min: 16, likely: 24, max: 40}}
+      - {name: XL, points: 8}
+      - {name: XXL, points: 13}
+
 EXAMPLES:
   # Calculate total points from JSON file
   sizely points --file examples/basic/tasks.json
@@ -107,6 +751,39 @@ EXAMPLES:
   sizely points --data '{"xs":3,"s":2,"m":1,"l":1}'
   sizely points -d '{"xs":3,"s":2,"m":1,"l":1}'
 
+  # Calculate points from an itemized backlog and list tasks per size
+  sizely points -f examples/backlog/sprint.yaml
+
+  # Compare a plan with the team's capacity
+  sizely points -f examples/basic/tasks.json --team examples/team/team.yaml
+
+  # Account for holidays and PTO during the sprint
+  sizely points -f examples/basic/tasks.json --team examples/team/team.yaml \
+    --sprint-start 2026-10-05 --sprint-end 2026-10-16 --calendar examples/team/calendar.yaml
+
+  # Pick tickets from a prioritized backlog for a 20 point sprint
+  sizely plan 20 -f examples/backlog/sprint.yaml
+  sizely plan 20 -f examples/backlog/sprint.yaml --count 4
+
+  # Record finished sprints and derive the next target from velocity
+  sizely velocity add --sprint 42 --planned '{"m":2,"l":1}' --completed '{"m":1,"l":1}'
+  sizely velocity --window 4
+
+  # How likely is this sprint to land, given the recorded velocity?
+  sizely points -f examples/basic/tasks.json --forecast
+
+  # When will the whole backlog be done?
+  sizely forecast -f examples/backlog/sprint.yaml --start 2026-11-02
+
+  # Read task counts from standard input
+  cat examples/basic/tasks.yaml | sizely points
+  some-export-tool | sizely points -f - --input-format json
+
+  # Calculate points from YAML
+  sizely points -f sprint.yaml
+  sizely points -d 'xs: 3
+  l: 1' --input-format yaml
+
   # Find all task combinations that sum to 33 points
   sizely tasks 33
 
@@ -114,11 +791,37 @@ EXAMPLES:
   sizely tasks 33 --count 10
   sizely tasks 33 -c 10
 
+  # Find combinations with at most one L, at least three XS and no M
+  sizely tasks 20 --max L=1 --min XS=3 --exclude M
+
   # Find combinations and output in JSON format
-  sizely tasks 33 --output-json
+  sizely tasks 33 --format json
   sizely tasks 33 -o
 
-JSON INPUT FORMAT:
+  # Pipe machine-readable results to other tools; errors go to stderr
+  sizely points -f examples/basic/tasks.json --format json | jq .capacity.total_points
+
+  # Generate a sprint announcement from a template
+  sizely points -f examples/basic/tasks.json --template examples/templates/announcement.tmpl
+  sizely tasks 13 -c 3 --template '{{range .Combinations}}{{label .}}{{"\n"}}{{end}}'
+
+  # Paste a sprint plan into a wiki page or a spreadsheet
+  sizely plan 20 -f examples/backlog/sprint.yaml --format markdown
+  sizely plan 20 -f examples/backlog/sprint.yaml --format csv > plan.csv
+
+  # Share a sprint plan with charts as a single HTML file
+  sizely points -f examples/basic/tasks.json --format html > sprint.html
+
+  # Compare the same plan under a different preset
+  sizely points -d '{"xs":3,"s":2,"m":1,"l":1}' --scale fibonacci
+  sizely tasks 21 --scale pow2
+
+  # Use a custom size scale
+  sizely points -d '{"m":2,"xl":1}' --scale-file scale.yaml
+  sizely tasks 21 --scale-file scale.yaml
+
+INPUT FORMAT:
+  JSON:
   {
     "xs": 2,  // Number of XS tasks
     "s": 3,   // Number of S tasks
@@ -126,5 +829,25 @@ JSON INPUT FORMAT:
     "l": 2    // Number of L tasks
   }
 
+  YAML:
+    xs: 2
+    s: 3
+    m: 1
+    l: 2
+
+  Itemized backlog (JSON array or YAML list):
+    [{"id": "API-12", "title": "Add rate limiting", "size": "M"}, ...]
+
+    - id: API-12
+      title: Add rate limiting
+      size: M
+      depends_on: [OPS-2]       # optional, used by plan
+
+  With --input-format auto, files are read by extension (.json, .yaml, .yml) and
+  other input is treated as JSON when it is valid JSON and as YAML otherwise, so
+  YAML flow style such as '{xs: 3, l: 1}' works too.
+
+  Keys are size names from the active scale (case-insensitive); omitted sizes count as zero.
+
 For more information, visit: https://github.com/gr1m0h/sizely`)
 }
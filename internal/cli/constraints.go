@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gr1m0h/sizely/internal/models"
+)
+
+// ParseConstraints builds combination constraints from SIZE=N minimums and maximums and excluded
+// size names; each value may also be a comma-separated list, e.g. "L=1,XL=0"
+func ParseConstraints(minimums, maximums, excluded []string) (models.Constraints, error) {
+	minCounts, err := parseCounts("min", minimums)
+	if err != nil {
+		return models.Constraints{}, err
+	}
+	maxCounts, err := parseCounts("max", maximums)
+	if err != nil {
+		return models.Constraints{}, err
+	}
+
+	return models.Constraints{Min: minCounts, Max: maxCounts, Exclude: splitList(excluded)}, nil
+}
+
+// parseCounts parses SIZE=N values of a min or max constraint
+func parseCounts(kind string, values []string) (models.TaskCount, error) {
+	items := splitList(values)
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	counts := make(models.TaskCount, len(items))
+	for _, item := range items {
+		name, value, ok := strings.Cut(item, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%s: invalid constraint %q, expected SIZE=N", kind, item)
+		}
+
+		count, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid count %q for %s, must be an integer", kind, value, name)
+		}
+		if _, dup := counts[name]; dup {
+			return nil, fmt.Errorf("%s: size %s is given more than once", kind, name)
+		}
+		counts[name] = count
+	}
+
+	return counts, nil
+}
+
+// splitList flattens comma-separated values, dropping empty items
+func splitList(values []string) []string {
+	var items []string
+	for _, value := range values {
+		for item := range strings.SplitSeq(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+
+	return items
+}
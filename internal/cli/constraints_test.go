@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/gr1m0h/sizely/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConstraints(t *testing.T) {
+	tests := []struct {
+		name     string
+		min      []string
+		max      []string
+		exclude  []string
+		expected models.Constraints
+		wantErr  string
+	}{
+		{name: "none", expected: models.Constraints{}},
+		{
+			name:     "repeated and comma-separated",
+			min:      []string{"XS=3"},
+			max:      []string{"L=1", "S=2, M=0"},
+			exclude:  []string{"M,XL", " L "},
+			expected: models.Constraints{Min: models.TaskCount{"XS": 3}, Max: models.TaskCount{"L": 1, "S": 2, "M": 0}, Exclude: []string{"M", "XL", "L"}},
+		},
+		{name: "missing count", max: []string{"L"}, wantErr: `max: invalid constraint "L", expected SIZE=N`},
+		{name: "missing size", min: []string{"=2"}, wantErr: `min: invalid constraint "=2"`},
+		{name: "non-integer count", min: []string{"XS=many"}, wantErr: `min: invalid count "many" for XS`},
+		{name: "duplicate size", max: []string{"L=1", "L=2"}, wantErr: "max: size L is given more than once"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			constraints, err := ParseConstraints(tt.min, tt.max, tt.exclude)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, constraints)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/gr1m0h/sizely/internal/config"
+	"github.com/gr1m0h/sizely/internal/render"
+	"golang.org/x/term"
+)
+
+// TerminalStyle returns the text style for output to f. The auto color mode colors terminals
+// unless NO_COLOR is set; a zero width uses the terminal's width, or COLUMNS when f is not a terminal.
+func TerminalStyle(f *os.File, cfg *config.Config, getenv func(string) string) render.Style {
+	style := render.Style{Width: cfg.Width, ASCII: cfg.ASCII}
+
+	fd := int(f.Fd())
+	terminal := term.IsTerminal(fd)
+
+	switch cfg.Color {
+	case config.ColorAlways:
+		style.Color = true
+	case config.ColorAuto:
+		style.Color = terminal && getenv("NO_COLOR") == ""
+	}
+
+	if style.Width == 0 && terminal {
+		if width, _, err := term.GetSize(fd); err == nil {
+			style.Width = width
+		}
+	}
+
+	if style.Width == 0 {
+		if columns, err := strconv.Atoi(getenv("COLUMNS")); err == nil && columns > 0 {
+			style.Width = columns
+		}
+	}
+
+	return style
+}
@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gr1m0h/sizely/internal/config"
+	"github.com/gr1m0h/sizely/internal/render"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTerminalStyle(t *testing.T) {
+	// A pipe stands in for redirected output: not a terminal
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	defer r.Close()
+	defer w.Close()
+
+	tests := []struct {
+		name     string
+		settings map[string]string
+		env      map[string]string
+		expected render.Style
+	}{
+		{name: "auto is plain when redirected", expected: render.Style{}},
+		{name: "always colors redirected output", settings: map[string]string{config.KeyColor: "always"}, env: map[string]string{"NO_COLOR": "1"}, expected: render.Style{Color: true}},
+		{name: "never", settings: map[string]string{config.KeyColor: "never"}, expected: render.Style{}},
+		{name: "ascii", settings: map[string]string{config.KeyASCII: "true"}, expected: render.Style{ASCII: true}},
+		{name: "COLUMNS", env: map[string]string{"COLUMNS": "120"}, expected: render.Style{Width: 120}},
+		{name: "width overrides COLUMNS", settings: map[string]string{config.KeyWidth: "60"}, env: map[string]string{"COLUMNS": "120"}, expected: render.Style{Width: 60}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Default()
+			for key, value := range tt.settings {
+				require.NoError(t, cfg.Set(key, value, "test"))
+			}
+
+			style := TerminalStyle(w, cfg, func(name string) string { return tt.env[name] })
+			assert.Equal(t, tt.expected, style)
+		})
+	}
+}
@@ -0,0 +1,451 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gr1m0h/sizely/internal/scale"
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectFileName is the project configuration file discovered by walking up from the working directory
+const ProjectFileName = ".sizely.yaml"
+
+// Configuration keys, as used in config files, environment variables and `sizely config show`
+const (
+	KeyScale                 = "scale"
+	KeyScaleFile             = "scale_file"
+	KeyMaxTasks              = "max_tasks"
+	KeyFormat                = "format"
+	KeyColor                 = "color"
+	KeyASCII                 = "ascii"
+	KeyWidth                 = "width"
+	KeyHistoryFile           = "history_file"
+	KeyAdviceLowTaskCount    = "advice.low_task_count"
+	KeyAdviceHighTaskCount   = "advice.high_task_count"
+	KeyAdviceHeavyLargeCount = "advice.heavy_large_count"
+	KeyAdviceManySmallCount  = "advice.many_small_count"
+)
+
+// Keys lists the effective configuration keys in display order
+var Keys = []string{
+	KeyScale,
+	KeyMaxTasks,
+	KeyFormat,
+	KeyColor,
+	KeyASCII,
+	KeyWidth,
+	KeyHistoryFile,
+	KeyAdviceLowTaskCount,
+	KeyAdviceHighTaskCount,
+	KeyAdviceHeavyLargeCount,
+	KeyAdviceManySmallCount,
+}
+
+// envKeys lists the keys that can be overridden by SIZELY_* environment variables: every key,
+// plus the scale file that may replace the scale
+var envKeys = append([]string{KeyScaleFile}, Keys...)
+
+// Formats lists the supported output formats
+var Formats = []string{"text", "json", "yaml", "csv", "markdown", "html"}
+
+// Color modes: auto colors terminals unless NO_COLOR is set
+const (
+	ColorAuto   = "auto"
+	ColorAlways = "always"
+	ColorNever  = "never"
+)
+
+// Colors lists the supported color modes
+var Colors = []string{ColorAuto, ColorAlways, ColorNever}
+
+// SourceDefault is the source of values that were not overridden
+const SourceDefault = "default"
+
+// DefaultHistoryFile is the velocity history location, relative to the project directory
+var DefaultHistoryFile = filepath.Join(".sizely", "history.jsonl")
+
+// Advice holds the thresholds used when commenting on task combinations
+type Advice struct {
+	LowTaskCount    int // at or below: focused work
+	HighTaskCount   int // at or above: context switching warning
+	HeavyLargeCount int // at or above: heavy on the largest size
+	ManySmallCount  int // at or above, of the smallest size and one fewer per size up: many quick wins
+}
+
+// Config represents the effective sizely configuration
+type Config struct {
+	Scale    scale.Scale
+	MaxTasks int
+	Format   string
+	Advice   Advice
+
+	// Color is the color mode of the text output, ASCII replaces its emoji and box-drawing
+	// characters and Width sets its width in columns (0 uses the terminal's width)
+	Color string
+	ASCII bool
+	Width int
+
+	// HistoryFile is the velocity history, by default under the directory holding the
+	// project config file (or the working directory when there is none)
+	HistoryFile string
+
+	// Files lists the configuration files that were loaded, lowest precedence first
+	Files []string
+
+	sources map[string]string
+}
+
+// fileConfig mirrors the YAML configuration file; nil fields are left unset
+type fileConfig struct {
+	Scale       *scale.Scale `yaml:"scale"`
+	ScaleFile   *string      `yaml:"scale_file"`
+	MaxTasks    *int         `yaml:"max_tasks"`
+	Format      *string      `yaml:"format"`
+	Color       *string      `yaml:"color"`
+	ASCII       *bool        `yaml:"ascii"`
+	Width       *int         `yaml:"width"`
+	HistoryFile *string      `yaml:"history_file"`
+	Advice      struct {
+		LowTaskCount    *int `yaml:"low_task_count"`
+		HighTaskCount   *int `yaml:"high_task_count"`
+		HeavyLargeCount *int `yaml:"heavy_large_count"`
+		ManySmallCount  *int `yaml:"many_small_count"`
+	} `yaml:"advice"`
+}
+
+// Default returns the built-in configuration
+func Default() *Config {
+	cfg := &Config{
+		Scale:       scale.Default(),
+		MaxTasks:    15,
+		Format:      "text",
+		Color:       ColorAuto,
+		HistoryFile: DefaultHistoryFile,
+		Advice: Advice{
+			LowTaskCount:    6,
+			HighTaskCount:   12,
+			HeavyLargeCount: 3,
+			ManySmallCount:  6,
+		},
+		sources: make(map[string]string, len(Keys)),
+	}
+
+	for _, key := range Keys {
+		cfg.sources[key] = SourceDefault
+	}
+
+	return cfg
+}
+
+// Load builds the configuration for dir by layering, from lowest to highest precedence,
+// the defaults, the user config file, the nearest project config file and SIZELY_* environment variables.
+// Flags are applied afterwards by the caller with Set, followed by Validate.
+func Load(dir string) (*Config, error) {
+	return load(dir, os.Getenv)
+}
+
+func load(dir string, getenv func(string) string) (*Config, error) {
+	cfg := Default()
+	cfg.HistoryFile = filepath.Join(dir, DefaultHistoryFile)
+
+	if path := UserFile(getenv); path != "" {
+		if err := cfg.mergeFile(path, "user config "+path); err != nil {
+			return nil, err
+		}
+	}
+
+	if path := FindProjectFile(dir); path != "" {
+		if cfg.Source(KeyHistoryFile) == SourceDefault {
+			cfg.HistoryFile = filepath.Join(filepath.Dir(path), DefaultHistoryFile)
+		}
+		if err := cfg.mergeFile(path, "project config "+path); err != nil {
+			return nil, err
+		}
+	}
+
+	if getenv(EnvName(KeyScale)) != "" && getenv(EnvName(KeyScaleFile)) != "" {
+		return nil, fmt.Errorf("set either %s or %s, not both", EnvName(KeyScale), EnvName(KeyScaleFile))
+	}
+
+	for _, key := range envKeys {
+		name := EnvName(key)
+		if value := getenv(name); value != "" {
+			if err := cfg.Set(key, value, "env "+name); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return cfg, nil
+}
+
+// UserFile returns the path of the user-level config file under the XDG config directory
+func UserFile(getenv func(string) string) string {
+	base := getenv("XDG_CONFIG_HOME")
+	if base == "" {
+		home := getenv("HOME")
+		if home == "" {
+			return ""
+		}
+		base = filepath.Join(home, ".config")
+	}
+
+	return filepath.Join(base, "sizely", "config.yaml")
+}
+
+// FindProjectFile walks up from dir and returns the first project config file found, or ""
+func FindProjectFile(dir string) string {
+	for {
+		path := filepath.Join(dir, ProjectFileName)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// EnvName returns the environment variable that overrides key, e.g. SIZELY_MAX_TASKS
+func EnvName(key string) string {
+	return "SIZELY_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// Source returns where the effective value for key came from
+func (c *Config) Source(key string) string {
+	return c.sources[key]
+}
+
+// Value returns the effective value for key formatted for display
+func (c *Config) Value(key string) string {
+	switch key {
+	case KeyScale:
+		return c.Scale.String()
+	case KeyMaxTasks:
+		return strconv.Itoa(c.MaxTasks)
+	case KeyFormat:
+		return c.Format
+	case KeyColor:
+		return c.Color
+	case KeyASCII:
+		return strconv.FormatBool(c.ASCII)
+	case KeyWidth:
+		return strconv.Itoa(c.Width)
+	case KeyHistoryFile:
+		return c.HistoryFile
+	case KeyAdviceLowTaskCount:
+		return strconv.Itoa(c.Advice.LowTaskCount)
+	case KeyAdviceHighTaskCount:
+		return strconv.Itoa(c.Advice.HighTaskCount)
+	case KeyAdviceHeavyLargeCount:
+		return strconv.Itoa(c.Advice.HeavyLargeCount)
+	case KeyAdviceManySmallCount:
+		return strconv.Itoa(c.Advice.ManySmallCount)
+	default:
+		return ""
+	}
+}
+
+// Set overrides a single value from its string form and records its source
+func (c *Config) Set(key, value, source string) error {
+	switch key {
+	case KeyScale:
+		s, err := scale.Preset(value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", source, err)
+		}
+		c.Scale = s
+	case KeyScaleFile:
+		s, err := scale.Load(value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", source, err)
+		}
+		c.Scale = s
+		c.sources[KeyScale] = fmt.Sprintf("%s (%s)", source, value)
+		return nil
+	case KeyFormat:
+		c.Format = strings.ToLower(value)
+	case KeyColor:
+		c.Color = strings.ToLower(value)
+	case KeyASCII:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%s: %s must be true or false, got %q", source, key, value)
+		}
+		c.ASCII = b
+	case KeyHistoryFile:
+		c.HistoryFile = value
+	case KeyMaxTasks, KeyWidth, KeyAdviceLowTaskCount, KeyAdviceHighTaskCount, KeyAdviceHeavyLargeCount, KeyAdviceManySmallCount:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%s: %s must be an integer, got %q", source, key, value)
+		}
+		*c.intField(key) = n
+	default:
+		return fmt.Errorf("%s: unknown configuration key %q", source, key)
+	}
+
+	c.sources[key] = source
+	return nil
+}
+
+// Validate checks that the effective configuration is usable
+func (c *Config) Validate() error {
+	if err := c.Scale.Validate(); err != nil {
+		return fmt.Errorf("%s: %w", KeyScale, err)
+	}
+
+	if c.MaxTasks <= 0 {
+		return fmt.Errorf("%s must be positive (from %s)", KeyMaxTasks, c.Source(KeyMaxTasks))
+	}
+
+	if c.HistoryFile == "" {
+		return fmt.Errorf("%s must not be empty (from %s)", KeyHistoryFile, c.Source(KeyHistoryFile))
+	}
+
+	if !slices.Contains(Formats, c.Format) {
+		return fmt.Errorf("%s must be one of %v, got %q (from %s)", KeyFormat, Formats, c.Format, c.Source(KeyFormat))
+	}
+
+	if !slices.Contains(Colors, c.Color) {
+		return fmt.Errorf("%s must be one of %v, got %q (from %s)", KeyColor, Colors, c.Color, c.Source(KeyColor))
+	}
+
+	for _, key := range []string{KeyWidth, KeyAdviceLowTaskCount, KeyAdviceHighTaskCount} {
+		if *c.intField(key) < 0 {
+			return fmt.Errorf("%s must not be negative (from %s)", key, c.Source(key))
+		}
+	}
+
+	// A threshold of zero would comment on combinations without any such tasks
+	for _, key := range []string{KeyAdviceHeavyLargeCount, KeyAdviceManySmallCount} {
+		if *c.intField(key) < 1 {
+			return fmt.Errorf("%s must be at least 1 (from %s)", key, c.Source(key))
+		}
+	}
+
+	if c.Advice.LowTaskCount >= c.Advice.HighTaskCount {
+		return fmt.Errorf("%s must be lower than %s", KeyAdviceLowTaskCount, KeyAdviceHighTaskCount)
+	}
+
+	return nil
+}
+
+// mergeFile applies every value set in the YAML file at path
+func (c *Config) mergeFile(path, source string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("reading config: %w", err)
+	}
+
+	var fc fileConfig
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&fc); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("parsing config %s: %w", path, err)
+	}
+
+	c.Files = append(c.Files, path)
+
+	if fc.Scale != nil && fc.ScaleFile != nil {
+		return fmt.Errorf("%s: set either %s or %s, not both", path, KeyScale, KeyScaleFile)
+	}
+
+	if fc.Scale != nil {
+		s, err := scale.New(fc.Scale.Name, fc.Scale.Sizes)
+		if err != nil {
+			return fmt.Errorf("%s: %s: %w", path, KeyScale, err)
+		}
+		c.Scale = s
+		c.sources[KeyScale] = source
+	}
+
+	if fc.ScaleFile != nil {
+		// Relative scale files are resolved against the directory of the config file
+		scaleFile := *fc.ScaleFile
+		if !filepath.IsAbs(scaleFile) {
+			scaleFile = filepath.Join(filepath.Dir(path), scaleFile)
+		}
+		if err := c.Set(KeyScaleFile, scaleFile, source); err != nil {
+			return err
+		}
+	}
+
+	if fc.MaxTasks != nil {
+		c.MaxTasks = *fc.MaxTasks
+		c.sources[KeyMaxTasks] = source
+	}
+
+	if fc.Format != nil {
+		c.Format = strings.ToLower(*fc.Format)
+		c.sources[KeyFormat] = source
+	}
+
+	if fc.Color != nil {
+		c.Color = strings.ToLower(*fc.Color)
+		c.sources[KeyColor] = source
+	}
+
+	if fc.ASCII != nil {
+		c.ASCII = *fc.ASCII
+		c.sources[KeyASCII] = source
+	}
+
+	if fc.HistoryFile != nil {
+		// Relative history files are resolved against the directory of the config file
+		c.HistoryFile = *fc.HistoryFile
+		if !filepath.IsAbs(c.HistoryFile) {
+			c.HistoryFile = filepath.Join(filepath.Dir(path), c.HistoryFile)
+		}
+		c.sources[KeyHistoryFile] = source
+	}
+
+	for key, value := range map[string]*int{
+		KeyWidth:                 fc.Width,
+		KeyAdviceLowTaskCount:    fc.Advice.LowTaskCount,
+		KeyAdviceHighTaskCount:   fc.Advice.HighTaskCount,
+		KeyAdviceHeavyLargeCount: fc.Advice.HeavyLargeCount,
+		KeyAdviceManySmallCount:  fc.Advice.ManySmallCount,
+	} {
+		if value != nil {
+			*c.intField(key) = *value
+			c.sources[key] = source
+		}
+	}
+
+	return nil
+}
+
+// intField returns a pointer to the integer setting stored under key
+func (c *Config) intField(key string) *int {
+	switch key {
+	case KeyMaxTasks:
+		return &c.MaxTasks
+	case KeyWidth:
+		return &c.Width
+	case KeyAdviceLowTaskCount:
+		return &c.Advice.LowTaskCount
+	case KeyAdviceHighTaskCount:
+		return &c.Advice.HighTaskCount
+	case KeyAdviceHeavyLargeCount:
+		return &c.Advice.HeavyLargeCount
+	case KeyAdviceManySmallCount:
+		return &c.Advice.ManySmallCount
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,218 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func envFunc(env map[string]string) func(string) string {
+	return func(key string) string {
+		return env[key]
+	}
+}
+
+func TestDefault(t *testing.T) {
+	cfg := Default()
+
+	assert.NoError(t, cfg.Validate())
+	assert.Equal(t, 15, cfg.MaxTasks)
+	assert.Equal(t, "text", cfg.Format)
+	assert.Equal(t, []string{"XS", "S", "M", "L"}, cfg.Scale.Names())
+	for _, key := range Keys {
+		assert.Equal(t, SourceDefault, cfg.Source(key))
+	}
+}
+
+func TestLoadLayering(t *testing.T) {
+	root := t.TempDir()
+	xdg := filepath.Join(root, "xdg")
+	project := filepath.Join(root, "project")
+	workDir := filepath.Join(project, "sub", "dir")
+	require.NoError(t, os.MkdirAll(workDir, 0o755))
+
+	userFile := filepath.Join(xdg, "sizely", "config.yaml")
+	projectFile := filepath.Join(project, ProjectFileName)
+	writeFile(t, userFile, "max_tasks: 10\nformat: json\nadvice:\n  high_task_count: 20\n")
+	writeFile(t, projectFile, "scale_file: scales/team.yaml\nmax_tasks: 8\n")
+	writeFile(t, filepath.Join(project, "scales", "team.yaml"), "name: team\nsizes:\n  - {name: S, points: 2}\n  - {name: XL, points: 13}\n")
+
+	cfg, err := load(workDir, envFunc(map[string]string{
+		"XDG_CONFIG_HOME":              xdg,
+		"SIZELY_ADVICE_LOW_TASK_COUNT": "4",
+	}))
+	require.NoError(t, err)
+	require.NoError(t, cfg.Set(KeyFormat, "text", "flag --format"))
+	require.NoError(t, cfg.Validate())
+
+	assert.Equal(t, []string{userFile, projectFile}, cfg.Files)
+
+	assert.Equal(t, []string{"S", "XL"}, cfg.Scale.Names())
+	assert.Contains(t, cfg.Source(KeyScale), "project config "+projectFile)
+
+	assert.Equal(t, filepath.Join(project, ".sizely", "history.jsonl"), cfg.HistoryFile)
+	assert.Equal(t, SourceDefault, cfg.Source(KeyHistoryFile))
+
+	assert.Equal(t, 8, cfg.MaxTasks)
+	assert.Equal(t, "project config "+projectFile, cfg.Source(KeyMaxTasks))
+
+	assert.Equal(t, "text", cfg.Format)
+	assert.Equal(t, "flag --format", cfg.Source(KeyFormat))
+
+	assert.Equal(t, 20, cfg.Advice.HighTaskCount)
+	assert.Equal(t, "user config "+userFile, cfg.Source(KeyAdviceHighTaskCount))
+
+	assert.Equal(t, 4, cfg.Advice.LowTaskCount)
+	assert.Equal(t, "env SIZELY_ADVICE_LOW_TASK_COUNT", cfg.Source(KeyAdviceLowTaskCount))
+
+	assert.Equal(t, SourceDefault, cfg.Source(KeyAdviceManySmallCount))
+}
+
+func TestLoadInlineScale(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, ProjectFileName), "scale:\n  name: inline\n  sizes:\n    - {name: L, points: 8}\n    - {name: M, points: 5}\n")
+
+	cfg, err := load(dir, envFunc(nil))
+	require.NoError(t, err)
+
+	assert.Equal(t, "inline (M=5, L=8)", cfg.Value(KeyScale))
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "Unknown key", content: "max_task: 3\n", wantErr: "max_task"},
+		{name: "Invalid scale", content: "scale:\n  sizes: []\n", wantErr: "at least one size"},
+		{name: "Scale and scale file", content: "scale: {sizes: [{name: M, points: 5}]}\nscale_file: x.yaml\n", wantErr: "not both"},
+		{name: "Missing scale file", content: "scale_file: missing.yaml\n", wantErr: "reading scale file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFile(t, filepath.Join(dir, ProjectFileName), tt.content)
+
+			_, err := load(dir, envFunc(nil))
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestSetAndValidate(t *testing.T) {
+	cfg := Default()
+
+	assert.ErrorContains(t, cfg.Set(KeyMaxTasks, "many", "env SIZELY_MAX_TASKS"), "must be an integer")
+	assert.ErrorContains(t, cfg.Set("colour", "red", "flag --colour"), "unknown configuration key")
+
+	require.NoError(t, cfg.Set(KeyFormat, "xml", "flag --format"))
+	assert.ErrorContains(t, cfg.Validate(), "format must be one of")
+
+	cfg = Default()
+	require.NoError(t, cfg.Set(KeyMaxTasks, "0", "flag --count"))
+	assert.ErrorContains(t, cfg.Validate(), "from flag --count")
+
+	cfg = Default()
+	require.NoError(t, cfg.Set(KeyAdviceLowTaskCount, "12", "flag"))
+	assert.ErrorContains(t, cfg.Validate(), "must be lower than")
+
+	for _, key := range []string{KeyAdviceHeavyLargeCount, KeyAdviceManySmallCount} {
+		cfg = Default()
+		require.NoError(t, cfg.Set(key, "0", "env "+EnvName(key)))
+		assert.ErrorContains(t, cfg.Validate(), key+" must be at least 1 (from env "+EnvName(key)+")")
+	}
+}
+
+func TestUserFile(t *testing.T) {
+	assert.Equal(t, filepath.Join("/xdg", "sizely", "config.yaml"), UserFile(envFunc(map[string]string{"XDG_CONFIG_HOME": "/xdg", "HOME": "/home/me"})))
+	assert.Equal(t, filepath.Join("/home/me", ".config", "sizely", "config.yaml"), UserFile(envFunc(map[string]string{"HOME": "/home/me"})))
+	assert.Equal(t, "", UserFile(envFunc(nil)))
+}
+
+func TestScalePreset(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, ProjectFileName), "scale: fibonacci\n")
+
+	cfg, err := load(dir, envFunc(nil))
+	require.NoError(t, err)
+	assert.Equal(t, "fibonacci", cfg.Scale.Name)
+
+	cfg, err = load(dir, envFunc(map[string]string{"SIZELY_SCALE": "pow2"}))
+	require.NoError(t, err)
+	assert.Equal(t, "pow2", cfg.Scale.Name)
+	assert.Equal(t, "env SIZELY_SCALE", cfg.Source(KeyScale))
+
+	require.NoError(t, cfg.Set(KeyScale, "linear", "flag --scale"))
+	assert.Equal(t, "linear", cfg.Scale.Name)
+	assert.ErrorContains(t, cfg.Set(KeyScale, "poker", "flag --scale"), "unknown scale preset")
+
+	_, err = load(dir, envFunc(map[string]string{"SIZELY_SCALE": "pow2", "SIZELY_SCALE_FILE": "team.yaml"}))
+	assert.ErrorContains(t, err, "not both")
+}
+
+func TestHistoryFile(t *testing.T) {
+	dir := t.TempDir()
+
+	cfg, err := load(dir, envFunc(nil))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, ".sizely", "history.jsonl"), cfg.HistoryFile)
+
+	writeFile(t, filepath.Join(dir, ProjectFileName), "history_file: data/velocity.jsonl\n")
+	cfg, err = load(filepath.Join(dir), envFunc(nil))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "data", "velocity.jsonl"), cfg.HistoryFile)
+
+	cfg, err = load(dir, envFunc(map[string]string{"SIZELY_HISTORY_FILE": "/tmp/history.jsonl"}))
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/history.jsonl", cfg.HistoryFile)
+	assert.Equal(t, "env SIZELY_HISTORY_FILE", cfg.Source(KeyHistoryFile))
+}
+
+func TestTerminalSettings(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, ProjectFileName), "color: Never\nascii: true\nwidth: 100\n")
+
+	cfg, err := load(dir, envFunc(map[string]string{"SIZELY_WIDTH": "60"}))
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+
+	assert.Equal(t, ColorNever, cfg.Color)
+	assert.True(t, cfg.ASCII)
+	assert.Equal(t, "true", cfg.Value(KeyASCII))
+	assert.Equal(t, 60, cfg.Width)
+	assert.Equal(t, "env SIZELY_WIDTH", cfg.Source(KeyWidth))
+
+	cfg = Default()
+	assert.ErrorContains(t, cfg.Set(KeyASCII, "sometimes", "flag --ascii"), "must be true or false")
+	require.NoError(t, cfg.Set(KeyColor, "rainbow", "flag --color"))
+	assert.ErrorContains(t, cfg.Validate(), "color must be one of [auto always never]")
+
+	cfg = Default()
+	require.NoError(t, cfg.Set(KeyWidth, "-1", "flag --width"))
+	assert.ErrorContains(t, cfg.Validate(), "width must not be negative")
+}
+
+func TestEveryKeyFromEnv(t *testing.T) {
+	env := make(map[string]string, len(Keys))
+	for _, key := range Keys {
+		env[EnvName(key)] = Default().Value(key)
+	}
+	env[EnvName(KeyScale)] = "tshirt"
+
+	cfg, err := load(t.TempDir(), envFunc(env))
+	require.NoError(t, err)
+	for _, key := range Keys {
+		assert.Equal(t, "env "+EnvName(key), cfg.Source(key), key)
+	}
+}
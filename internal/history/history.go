@@ -0,0 +1,99 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gr1m0h/sizely/internal/models"
+)
+
+// Store is a JSON lines file holding one sprint record per line
+type Store struct {
+	path string
+}
+
+// NewStore creates a Store backed by the file at path
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// Path returns the location of the history file
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Load reads every recorded sprint, oldest first; a missing file means no history yet
+func (s *Store) Load() ([]models.SprintRecord, error) {
+	file, err := os.Open(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading history: %w", err)
+	}
+	defer file.Close()
+
+	var records []models.SprintRecord
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var record models.SprintRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("%s:%d: parsing record: %w", s.path, line, err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading history: %w", err)
+	}
+
+	return records, nil
+}
+
+// Append adds a sprint record, rejecting a sprint name that was already recorded
+func (s *Store) Append(record models.SprintRecord) error {
+	if record.Sprint == "" {
+		return fmt.Errorf("sprint name must not be empty")
+	}
+
+	records, err := s.Load()
+	if err != nil {
+		return err
+	}
+	for _, r := range records {
+		if r.Sprint == record.Sprint {
+			return fmt.Errorf("sprint %q is already recorded in %s", record.Sprint, s.path)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("creating history directory: %w", err)
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("encoding record: %w", err)
+	}
+
+	file, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("opening history: %w", err)
+	}
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return fmt.Errorf("writing history: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("writing history: %w", err)
+	}
+
+	return nil
+}
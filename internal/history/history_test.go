@@ -0,0 +1,49 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gr1m0h/sizely/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), ".sizely", "history.jsonl"))
+
+	records, err := store.Load()
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	first := models.SprintRecord{
+		Sprint:          "41",
+		Start:           "2026-09-21",
+		End:             "2026-10-02",
+		Scale:           "tshirt",
+		Planned:         models.TaskCount{"XS": 2, "S": 1, "M": 2, "L": 1},
+		Completed:       models.TaskCount{"XS": 2, "S": 1, "M": 1, "L": 1},
+		PlannedPoints:   25,
+		CompletedPoints: 20,
+	}
+	second := models.SprintRecord{Sprint: "42", PlannedPoints: 20, CompletedPoints: 18}
+
+	require.NoError(t, store.Append(first))
+	require.NoError(t, store.Append(second))
+
+	records, err = store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, []models.SprintRecord{first, second}, records)
+
+	assert.ErrorContains(t, store.Append(models.SprintRecord{Sprint: "42"}), `sprint "42" is already recorded`)
+	assert.ErrorContains(t, store.Append(models.SprintRecord{}), "must not be empty")
+}
+
+func TestStoreCorruptLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte("{\"sprint\":\"1\"}\n\nnot json\n"), 0o644))
+
+	_, err := NewStore(path).Load()
+	assert.ErrorContains(t, err, "history.jsonl:3")
+}
@@ -0,0 +1,157 @@
+package input
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrEmpty is returned when an input document contains no data
+var ErrEmpty = errors.New("input is empty")
+
+// StdinName is the file name that selects standard input, as in `sizely points -f -`
+const StdinName = "-"
+
+// Format identifies the encoding of an input document
+type Format string
+
+const (
+	// FormatAuto detects the format from the document contents
+	FormatAuto Format = "auto"
+	// FormatJSON decodes the document as JSON
+	FormatJSON Format = "json"
+	// FormatYAML decodes the document as YAML
+	FormatYAML Format = "yaml"
+)
+
+// ParseFormat converts a --input-format value into a Format
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(s) {
+	case "", string(FormatAuto):
+		return FormatAuto, nil
+	case string(FormatJSON):
+		return FormatJSON, nil
+	case string(FormatYAML), "yml":
+		return FormatYAML, nil
+	default:
+		return "", fmt.Errorf("unknown input format %q (expected auto, json or yaml)", s)
+	}
+}
+
+// FormatForFile returns the format implied by the file extension, or format itself when it is explicit
+func FormatForFile(filename string, format Format) Format {
+	if format != FormatAuto {
+		return format
+	}
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".json":
+		return FormatJSON
+	case ".yaml", ".yml":
+		return FormatYAML
+	default:
+		return FormatAuto
+	}
+}
+
+// Detect guesses the format of data: valid JSON documents are JSON, anything else YAML, which
+// also covers flow style such as {xs: 3, l: 1}
+func Detect(data []byte) Format {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') && json.Valid(trimmed) {
+		return FormatJSON
+	}
+
+	return FormatYAML
+}
+
+// Decode unmarshals data in the given format into v
+func Decode(data []byte, format Format, v any) error {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return ErrEmpty
+	}
+
+	if format == FormatAuto {
+		format = Detect(data)
+	}
+
+	switch format {
+	case FormatJSON:
+		if err := json.Unmarshal(data, v); err != nil {
+			return fmt.Errorf("parsing JSON: %w", err)
+		}
+	case FormatYAML:
+		if err := yaml.Unmarshal(data, v); err != nil {
+			return fmt.Errorf("parsing YAML: %w", err)
+		}
+	default:
+		return fmt.Errorf("unsupported input format %q", format)
+	}
+
+	return nil
+}
+
+// ReadFile reads a document from filename, or from standard input when filename is StdinName,
+// and resolves an auto format from the file extension
+func ReadFile(filename string, format Format) ([]byte, Format, error) {
+	if filename == StdinName {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, format, fmt.Errorf("reading input: %w", err)
+		}
+		return data, format, nil
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, format, fmt.Errorf("reading file: %w", err)
+	}
+
+	return data, FormatForFile(filename, format), nil
+}
+
+// IsList reports whether data holds a top-level list (a JSON array or YAML sequence)
+func IsList(data []byte, format Format) (bool, error) {
+	var doc any
+	if err := Decode(data, format, &doc); err != nil {
+		return false, err
+	}
+
+	_, ok := doc.([]any)
+	return ok, nil
+}
+
+// ReadPiped reads all of f when it is a pipe or a regular file, as standard input is in
+// `cat tasks.json | sizely points` or `sizely points < tasks.json`. It reports false when f is
+// a terminal, a device such as /dev/null or holds nothing but whitespace, as standard input
+// often does under CI and cron, so that callers can show their usage instead.
+func ReadPiped(f *os.File) ([]byte, bool, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return nil, false, nil
+	}
+
+	switch mode := info.Mode(); {
+	case mode.IsRegular() && info.Size() == 0:
+		return nil, false, nil
+	case !mode.IsRegular() && mode&os.ModeNamedPipe == 0:
+		return nil, false, nil
+	}
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, false, fmt.Errorf("reading input: %w", err)
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, false, nil
+	}
+
+	return data, true, nil
+}
@@ -0,0 +1,130 @@
+package input
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFormat(t *testing.T) {
+	for value, want := range map[string]Format{"": FormatAuto, "auto": FormatAuto, "JSON": FormatJSON, "yaml": FormatYAML, "yml": FormatYAML} {
+		got, err := ParseFormat(value)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, value)
+	}
+
+	_, err := ParseFormat("toml")
+	assert.ErrorContains(t, err, "unknown input format")
+}
+
+func TestFormatForFile(t *testing.T) {
+	assert.Equal(t, FormatYAML, FormatForFile("plan.yaml", FormatAuto))
+	assert.Equal(t, FormatYAML, FormatForFile("plan.YML", FormatAuto))
+	assert.Equal(t, FormatJSON, FormatForFile("plan.json", FormatAuto))
+	assert.Equal(t, FormatAuto, FormatForFile("plan.txt", FormatAuto))
+	assert.Equal(t, FormatJSON, FormatForFile("plan.yaml", FormatJSON))
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		format Format
+	}{
+		{name: "JSON", data: `{"xs": 3, "l": 1}`, format: FormatJSON},
+		{name: "YAML", data: "xs: 3\nl: 1\n", format: FormatYAML},
+		{name: "Detected JSON", data: "  {\"xs\": 3, \"l\": 1}", format: FormatAuto},
+		{name: "Detected YAML", data: "# sprint 42\nxs: 3\nl: 1\n", format: FormatAuto},
+		{name: "Detected YAML flow style", data: "{xs: 3, l: 1}", format: FormatAuto},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var counts map[string]int
+			require.NoError(t, Decode([]byte(tt.data), tt.format, &counts))
+			assert.Equal(t, map[string]int{"xs": 3, "l": 1}, counts)
+		})
+	}
+
+	var counts map[string]int
+	assert.ErrorContains(t, Decode([]byte("xs: 3\n"), FormatJSON, &counts), "parsing JSON")
+	assert.ErrorContains(t, Decode([]byte("xs: [3\n"), FormatYAML, &counts), "parsing YAML")
+	assert.ErrorIs(t, Decode([]byte(" \n"), FormatAuto, &counts), ErrEmpty)
+	assert.ErrorContains(t, Decode([]byte(`{"xs": "three"}`), FormatAuto, &counts), "parsing JSON")
+}
+
+func TestIsList(t *testing.T) {
+	for data, want := range map[string]bool{
+		`[{"id": "API-1", "size": "M"}]`: true,
+		"- id: API-1\n  size: M\n":       true,
+		`{"m": 1}`:                       false,
+		"m: 1\n":                         false,
+	} {
+		got, err := IsList([]byte(data), FormatAuto)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, data)
+	}
+}
+
+func TestReadPiped(t *testing.T) {
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	_, err = w.WriteString(`{"m": 1}`)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	data, ok, err := ReadPiped(r)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, `{"m": 1}`, string(data))
+	require.NoError(t, r.Close())
+
+	// A pipe that is closed without data, as standard input often is under CI
+	r, w, err = os.Pipe()
+	require.NoError(t, err)
+	_, err = w.WriteString("\n")
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	_, ok, err = ReadPiped(r)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	require.NoError(t, r.Close())
+
+	file := filepath.Join(t.TempDir(), "tasks.json")
+	require.NoError(t, os.WriteFile(file, []byte(`{"s": 2}`), 0o644))
+	f, err := os.Open(file)
+	require.NoError(t, err)
+	defer f.Close()
+
+	data, ok, err = ReadPiped(f)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, `{"s": 2}`, string(data))
+
+	empty := filepath.Join(t.TempDir(), "empty.json")
+	require.NoError(t, os.WriteFile(empty, nil, 0o644))
+	f, err = os.Open(empty)
+	require.NoError(t, err)
+	defer f.Close()
+
+	_, ok, err = ReadPiped(f)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	devNull, err := os.Open(os.DevNull)
+	require.NoError(t, err)
+	defer devNull.Close()
+
+	_, ok, err = ReadPiped(devNull)
+	require.NoError(t, err)
+	assert.False(t, ok, "devices are not read")
+
+	require.NoError(t, devNull.Close())
+	_, ok, err = ReadPiped(devNull)
+	require.NoError(t, err)
+	assert.False(t, ok, "closed files cannot be read from")
+}
@@ -0,0 +1,192 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime/debug"
+	"slices"
+
+	"github.com/gr1m0h/sizely/pkg/sizely"
+)
+
+// MaxMessageBytes limits the size of a single JSON-RPC message
+const MaxMessageBytes = 4 << 20
+
+// ProtocolVersions lists the supported MCP protocol versions, latest first
+var ProtocolVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+// JSON-RPC error codes
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeInternalError  = -32603
+)
+
+// Server serves the sizely calculations as Model Context Protocol tools over a stream of
+// newline-delimited JSON-RPC messages, such as the standard input and output of a process
+type Server struct {
+	estimator *sizely.Estimator
+	tools     []tool
+}
+
+// request is a JSON-RPC request, or a notification when it has no ID
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// response is a JSON-RPC response carrying either a result or an error
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// rpcError is the error of a failed JSON-RPC request
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// New creates a server backed by est; its max tasks is the default max_tasks of the tools
+func New(est *sizely.Estimator) *Server {
+	s := &Server{estimator: est}
+	s.tools = s.newTools()
+
+	return s
+}
+
+// Serve answers the messages read from r on w until r is exhausted or ctx is canceled
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	lines := make(chan []byte)
+	errs := make(chan error, 1)
+	go func() {
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), MaxMessageBytes)
+		for scanner.Scan() {
+			select {
+			case lines <- bytes.Clone(scanner.Bytes()):
+			case <-ctx.Done():
+				return
+			}
+		}
+		errs <- scanner.Err()
+	}()
+
+	encoder := json.NewEncoder(w)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-errs:
+			if err != nil {
+				return fmt.Errorf("reading message: %w", err)
+			}
+			return nil
+		case line := <-lines:
+			if len(bytes.TrimSpace(line)) == 0 {
+				continue
+			}
+
+			resp := s.handle(ctx, line)
+			if resp == nil {
+				continue
+			}
+			if err := encoder.Encode(resp); err != nil {
+				return fmt.Errorf("writing response: %w", err)
+			}
+		}
+	}
+}
+
+// handle answers a single message, returning nil for notifications; ctx bounds the tools it runs
+func (s *Server) handle(ctx context.Context, message []byte) *response {
+	var req request
+	if err := json.Unmarshal(message, &req); err != nil {
+		if !json.Valid(message) {
+			return errorResponse(nil, &rpcError{Code: codeParseError, Message: "parse error: " + err.Error()})
+		}
+		return errorResponse(nil, &rpcError{Code: codeInvalidRequest, Message: "invalid request: expected a single JSON-RPC 2.0 request object"})
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return errorResponse(req.ID, &rpcError{Code: codeInvalidRequest, Message: `invalid request: jsonrpc must be "2.0" and method must be set`})
+	}
+
+	notification := len(req.ID) == 0 || string(req.ID) == "null"
+	result, err := s.dispatch(ctx, req)
+	if notification {
+		return nil
+	}
+	if err != nil {
+		return errorResponse(req.ID, err)
+	}
+
+	return &response{JSONRPC: "2.0", ID: req.ID, Result: result}
+}
+
+// dispatch runs the method of req
+func (s *Server) dispatch(ctx context.Context, req request) (any, *rpcError) {
+	switch req.Method {
+	case "initialize":
+		return s.initialize(req.Params)
+	case "ping":
+		return struct{}{}, nil
+	case "tools/list":
+		return map[string]any{"tools": s.tools}, nil
+	case "tools/call":
+		return s.callTool(ctx, req.Params)
+	default:
+		return nil, &rpcError{Code: codeMethodNotFound, Message: fmt.Sprintf("method not found: %s", req.Method)}
+	}
+}
+
+// initialize negotiates the protocol version and describes the server
+func (s *Server) initialize(params json.RawMessage) (any, *rpcError) {
+	var init struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}
+	if err := json.Unmarshal(params, &init); len(params) > 0 && err != nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: "invalid initialize params: " + err.Error()}
+	}
+
+	version := ProtocolVersions[0]
+	if slices.Contains(ProtocolVersions, init.ProtocolVersion) {
+		version = init.ProtocolVersion
+	}
+
+	return map[string]any{
+		"protocolVersion": version,
+		"capabilities":    map[string]any{"tools": map[string]any{"listChanged": false}},
+		"serverInfo":      map[string]string{"name": "sizely", "version": serverVersion()},
+		"instructions": fmt.Sprintf("sizely estimates sprints with the %s scale. Use calculate_points for the points of task counts, "+
+			"find_combinations for the task mixes adding up to a point target and plan_backlog to pick prioritized tickets for a sprint.",
+			s.estimator.Scale()),
+	}, nil
+}
+
+// serverVersion returns the module version sizely was built from
+func serverVersion() string {
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" {
+		return info.Main.Version
+	}
+
+	return "(devel)"
+}
+
+// errorResponse builds the response reporting err for the request with id
+func errorResponse(id json.RawMessage, err *rpcError) *response {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+
+	return &response{JSONRPC: "2.0", ID: id, Error: err}
+}
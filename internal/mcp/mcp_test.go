@@ -0,0 +1,283 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gr1m0h/sizely/internal/calculator"
+	"github.com/gr1m0h/sizely/internal/models"
+	"github.com/gr1m0h/sizely/pkg/sizely"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testResponse is a decoded JSON-RPC response
+type testResponse struct {
+	ID     json.RawMessage `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+// newServer creates a server backed by an estimator with opts
+func newServer(t *testing.T, opts ...sizely.Option) *Server {
+	t.Helper()
+
+	est, err := sizely.New(opts...)
+	require.NoError(t, err)
+
+	return New(est)
+}
+
+// exchange sends the messages to a new server and returns its responses
+func exchange(t *testing.T, messages ...string) []testResponse {
+	t.Helper()
+
+	var out strings.Builder
+	s := newServer(t)
+	require.NoError(t, s.Serve(context.Background(), strings.NewReader(strings.Join(messages, "\n")+"\n"), &out))
+
+	var responses []testResponse
+	scanner := bufio.NewScanner(strings.NewReader(out.String()))
+	for scanner.Scan() {
+		var resp testResponse
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &resp), scanner.Text())
+		responses = append(responses, resp)
+	}
+
+	return responses
+}
+
+// call returns the result of a single tools/call of name with arguments
+func call(t *testing.T, name, arguments string) toolResultBody {
+	t.Helper()
+
+	responses := exchange(t, `{"jsonrpc": "2.0", "id": 1, "method": "tools/call", "params": {"name": "`+name+`", "arguments": `+arguments+`}}`)
+	require.Len(t, responses, 1)
+	require.Nil(t, responses[0].Error)
+
+	var result toolResultBody
+	require.NoError(t, json.Unmarshal(responses[0].Result, &result))
+	require.Len(t, result.Content, 1)
+
+	return result
+}
+
+// toolResultBody is a decoded tools/call result
+type toolResultBody struct {
+	Content           []textContent   `json:"content"`
+	StructuredContent json.RawMessage `json:"structuredContent"`
+	IsError           bool            `json:"isError"`
+}
+
+func TestInitialize(t *testing.T) {
+	responses := exchange(t,
+		`{"jsonrpc": "2.0", "id": 1, "method": "initialize", "params": {"protocolVersion": "2025-03-26", "capabilities": {}, "clientInfo": {"name": "test", "version": "1"}}}`,
+		`{"jsonrpc": "2.0", "method": "notifications/initialized"}`,
+		`{"jsonrpc": "2.0", "id": "two", "method": "initialize", "params": {"protocolVersion": "1999-01-01"}}`,
+		`{"jsonrpc": "2.0", "id": 3, "method": "ping"}`,
+	)
+	require.Len(t, responses, 3)
+
+	var result struct {
+		ProtocolVersion string `json:"protocolVersion"`
+		Capabilities    struct {
+			Tools map[string]any `json:"tools"`
+		} `json:"capabilities"`
+		ServerInfo struct {
+			Name string `json:"name"`
+		} `json:"serverInfo"`
+	}
+	require.NoError(t, json.Unmarshal(responses[0].Result, &result))
+	assert.Equal(t, "2025-03-26", result.ProtocolVersion)
+	assert.NotNil(t, result.Capabilities.Tools)
+	assert.Equal(t, "sizely", result.ServerInfo.Name)
+
+	assert.JSONEq(t, `"two"`, string(responses[1].ID))
+	require.NoError(t, json.Unmarshal(responses[1].Result, &result))
+	assert.Equal(t, ProtocolVersions[0], result.ProtocolVersion)
+
+	assert.JSONEq(t, `3`, string(responses[2].ID))
+	assert.JSONEq(t, `{}`, string(responses[2].Result))
+}
+
+func TestListTools(t *testing.T) {
+	responses := exchange(t, `{"jsonrpc": "2.0", "id": 1, "method": "tools/list"}`)
+	require.Len(t, responses, 1)
+
+	var result struct {
+		Tools []struct {
+			Name         string  `json:"name"`
+			InputSchema  *Schema `json:"inputSchema"`
+			OutputSchema *Schema `json:"outputSchema"`
+		} `json:"tools"`
+	}
+	require.NoError(t, json.Unmarshal(responses[0].Result, &result))
+
+	names := make([]string, len(result.Tools))
+	for i, tool := range result.Tools {
+		names[i] = tool.Name
+		assert.Equal(t, "object", tool.InputSchema.Type, tool.Name)
+		assert.Equal(t, "object", tool.OutputSchema.Type, tool.Name)
+	}
+	assert.Equal(t, []string{"calculate_points", "find_combinations", "plan_backlog"}, names)
+
+	tasks := result.Tools[0].InputSchema.Properties["tasks"]
+	assert.Equal(t, []string{"tasks"}, result.Tools[0].InputSchema.Required)
+	assert.Equal(t, "object", tasks.Type)
+	assert.ElementsMatch(t, []string{"XS", "S", "M", "L"}, keys(tasks.Properties))
+	assert.Contains(t, tasks.Properties["L"].Description, "10 points")
+
+	combinations := result.Tools[1]
+	assert.Equal(t, []string{"points"}, combinations.InputSchema.Required)
+	assert.Equal(t, MaxTasksLimit, *combinations.InputSchema.Properties["max_tasks"].Maximum)
+	assert.Equal(t, MaxPointsLimit, *combinations.InputSchema.Properties["points"].Maximum)
+	assert.ElementsMatch(t, []string{"target_points", "max_tasks", "combinations", "total_found"}, combinations.OutputSchema.Required)
+	assert.Equal(t, MaxTasksLimit, *result.Tools[2].InputSchema.Properties["max_tasks"].Maximum)
+	combination := combinations.OutputSchema.Properties["combinations"].Items
+	assert.Equal(t, "object", combination.Properties["counts"].Type)
+	assert.Equal(t, "integer", combination.Properties["points"].Type)
+
+	assert.Equal(t, MaxItemsLimit, *result.Tools[2].InputSchema.Properties["items"].MaxItems)
+	item := result.Tools[2].InputSchema.Properties["items"].Items
+	assert.ElementsMatch(t, []string{"id", "size"}, item.Required)
+	assert.Equal(t, "array", item.Properties["depends_on"].Type)
+	planned := result.Tools[2].OutputSchema.Properties["selected"].Items
+	assert.Contains(t, planned.Properties, "id", "embedded backlog item fields are flattened")
+	assert.Contains(t, planned.Properties, "rank")
+}
+
+func TestCallTools(t *testing.T) {
+	result := call(t, "calculate_points", `{"tasks": {"xs": 3, "S": 2, "M": 1, "L": 1}}`)
+	assert.False(t, result.IsError)
+	var capacity models.SprintCapacity
+	require.NoError(t, json.Unmarshal(result.StructuredContent, &capacity))
+	assert.Equal(t, 24, capacity.TotalPoints)
+	assert.Equal(t, 7, capacity.TotalTasks)
+	assert.JSONEq(t, string(result.StructuredContent), result.Content[0].Text)
+
+	result = call(t, "find_combinations", `{"points": 13, "max_tasks": 3}`)
+	assert.False(t, result.IsError)
+	var combinations models.CombinationResult
+	require.NoError(t, json.Unmarshal(result.StructuredContent, &combinations))
+	assert.Equal(t, calculator.NewCalculator().FindCombinations(13, 3), combinations)
+
+	result = call(t, "plan_backlog", `{"points": 5, "items": [{"id": "A", "size": "M"}, {"id": "B", "size": "L"}, {"id": "C", "size": "S", "depends_on": ["A"]}]}`)
+	assert.False(t, result.IsError)
+	var plan models.PlanResult
+	require.NoError(t, json.Unmarshal(result.StructuredContent, &plan))
+	assert.Equal(t, 5, plan.SelectedPoints)
+	assert.Equal(t, 15, plan.MaxTasks)
+}
+
+func TestCallToolErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		tool      string
+		arguments string
+		wantError string
+	}{
+		{name: "Unknown size", tool: "calculate_points", arguments: `{"tasks": {"XXL": 1}}`, wantError: `unknown size "XXL"`},
+		{name: "Missing tasks", tool: "calculate_points", arguments: `{}`, wantError: "tasks is required"},
+		{name: "Unknown argument", tool: "find_combinations", arguments: `{"points": 5, "count": 3}`, wantError: `unknown field "count"`},
+		{name: "Wrong type", tool: "find_combinations", arguments: `{"points": "five"}`, wantError: "invalid arguments"},
+		{name: "Missing points", tool: "find_combinations", arguments: `{}`, wantError: "points must be positive"},
+		{name: "Too many tasks", tool: "find_combinations", arguments: `{"points": 5, "max_tasks": 101}`, wantError: "at most 100"},
+		{name: "Too many points", tool: "find_combinations", arguments: `{"points": 1001}`, wantError: "points must be at most 1000"},
+		{name: "Too many plan points", tool: "plan_backlog", arguments: `{"points": 1001, "items": []}`, wantError: "points must be at most 1000"},
+		{name: "Too many items", tool: "plan_backlog", arguments: `{"points": 5, "items": [` + strings.Repeat(`{"size": "S"},`, MaxItemsLimit) + `{"size": "S"}]}`, wantError: "at most 200 backlog items"},
+		{name: "Too many plan tasks", tool: "plan_backlog", arguments: `{"points": 5, "items": [], "max_tasks": 101}`, wantError: "at most 100"},
+		{name: "Negative max tasks", tool: "plan_backlog", arguments: `{"points": 5, "items": [], "max_tasks": -1}`, wantError: "max_tasks must be positive"},
+		{name: "Dependency cycle", tool: "plan_backlog", arguments: `{"points": 5, "items": [{"id": "A", "size": "S", "depends_on": ["A"]}]}`, wantError: "A"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := call(t, tt.tool, tt.arguments)
+			assert.True(t, result.IsError)
+			assert.Contains(t, result.Content[0].Text, tt.wantError)
+			assert.Empty(t, result.StructuredContent)
+		})
+	}
+}
+
+func TestCallToolLimits(t *testing.T) {
+	s := newServer(t, sizely.WithScalePreset("fibonacci"))
+
+	result, rpcErr := s.callTool(context.Background(), json.RawMessage(`{"name": "find_combinations", "arguments": {"points": 200, "max_tasks": 100}}`))
+	require.Nil(t, rpcErr)
+	assert.True(t, result.(toolResult).IsError)
+	assert.Equal(t, "more than 10000 combinations found, lower points or max_tasks", result.(toolResult).Content[0].Text)
+
+	// Calls stop once the server's context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, params := range []string{
+		`{"name": "find_combinations", "arguments": {"points": 13}}`,
+		`{"name": "plan_backlog", "arguments": {"points": 5, "items": [{"size": "S"}]}}`,
+	} {
+		result, rpcErr := s.callTool(ctx, json.RawMessage(params))
+		require.Nil(t, rpcErr)
+		assert.True(t, result.(toolResult).IsError)
+		assert.Equal(t, context.Canceled.Error(), result.(toolResult).Content[0].Text)
+	}
+}
+
+func TestProtocolErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		code    int
+	}{
+		{name: "Parse error", message: `{"jsonrpc": "2.0", "id": 1,`, code: codeParseError},
+		{name: "Batch", message: `[{"jsonrpc": "2.0", "id": 1, "method": "ping"}]`, code: codeInvalidRequest},
+		{name: "Wrong version", message: `{"jsonrpc": "1.0", "id": 1, "method": "ping"}`, code: codeInvalidRequest},
+		{name: "Unknown method", message: `{"jsonrpc": "2.0", "id": 1, "method": "resources/list"}`, code: codeMethodNotFound},
+		{name: "Unknown tool", message: `{"jsonrpc": "2.0", "id": 1, "method": "tools/call", "params": {"name": "estimate"}}`, code: codeInvalidParams},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responses := exchange(t, tt.message)
+			require.Len(t, responses, 1)
+			require.NotNil(t, responses[0].Error)
+			assert.Equal(t, tt.code, responses[0].Error.Code)
+		})
+	}
+
+	assert.Empty(t, exchange(t, `{"jsonrpc": "2.0", "method": "notifications/unknown"}`, ""), "notifications are never answered")
+}
+
+func TestServeStopsOnCancel(t *testing.T) {
+	r, w := io.Pipe()
+	defer w.Close()
+
+	s := newServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Serve(ctx, r, io.Discard)
+	}()
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve did not return after cancel")
+	}
+}
+
+// keys returns the keys of m
+func keys(m map[string]*Schema) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+
+	return names
+}
@@ -0,0 +1,115 @@
+package mcp
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gr1m0h/sizely/internal/models"
+	"github.com/gr1m0h/sizely/internal/scale"
+)
+
+// Schema is the subset of JSON Schema used to describe tool arguments and results
+type Schema struct {
+	Type                 string             `json:"type,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Minimum              *int               `json:"minimum,omitempty"`
+	Maximum              *int               `json:"maximum,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+}
+
+var taskCountType = reflect.TypeFor[models.TaskCount]()
+
+// schemaFor derives the schema of the JSON encoding of t from its json struct tags; fields
+// without omitempty are required, and task counts list the sizes of s
+func schemaFor(t reflect.Type, s scale.Scale) *Schema {
+	if t == taskCountType {
+		return taskCountSchema(s)
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return schemaFor(t.Elem(), s)
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: schemaFor(t.Elem(), s)}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: schemaFor(t.Elem(), s)}
+	case reflect.Struct:
+		schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+		addFields(schema, t, s)
+		return schema
+	default:
+		panic(fmt.Sprintf("mcp: no schema for %s", t))
+	}
+}
+
+// addFields adds the properties of the struct t to schema, flattening embedded structs
+// the way encoding/json does
+func addFields(schema *Schema, t reflect.Type, s scale.Scale) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			addFields(schema, field.Type, s)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := schemaFor(field.Type, s)
+		if description := field.Tag.Get("description"); description != "" {
+			property.Description = description
+		}
+		schema.Properties[name] = property
+		if !strings.Contains(options, "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// taskCountSchema describes task counts keyed by the size names of s
+func taskCountSchema(s scale.Scale) *Schema {
+	schema := &Schema{
+		Type:                 "object",
+		Description:          fmt.Sprintf("Number of tasks per size of the %s scale; size names are case-insensitive", s.Name),
+		Properties:           make(map[string]*Schema, len(s.Sizes)),
+		AdditionalProperties: &Schema{Type: "integer", Minimum: intPtr(0)},
+	}
+
+	for _, size := range s.Sizes {
+		schema.Properties[size.Name] = &Schema{
+			Type:        "integer",
+			Description: fmt.Sprintf("Number of %s tasks (%d points each)", size.Name, size.Points),
+			Minimum:     intPtr(0),
+		}
+	}
+
+	return schema
+}
+
+// intPtr returns a pointer to n
+func intPtr(n int) *int {
+	return &n
+}
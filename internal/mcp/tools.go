@@ -0,0 +1,257 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/gr1m0h/sizely/internal/models"
+	"github.com/gr1m0h/sizely/pkg/sizely"
+)
+
+// MaxTasksLimit bounds max_tasks so a single call cannot search an unbounded number of combinations
+const MaxTasksLimit = 100
+
+// MaxPointsLimit bounds points so a single call cannot search an unbounded point range
+const MaxPointsLimit = 1000
+
+// MaxItemsLimit bounds the backlog items a single plan_backlog call may search
+const MaxItemsLimit = 200
+
+// MaxCombinationsLimit bounds the combinations a single find_combinations call may return
+const MaxCombinationsLimit = 10000
+
+// tool is an MCP tool: its description as listed by tools/list and the function running it
+type tool struct {
+	Name         string  `json:"name"`
+	Title        string  `json:"title"`
+	Description  string  `json:"description"`
+	InputSchema  *Schema `json:"inputSchema"`
+	OutputSchema *Schema `json:"outputSchema"`
+
+	run func(ctx context.Context, arguments json.RawMessage) (any, error)
+}
+
+// pointsArgs are the arguments of calculate_points
+type pointsArgs struct {
+	Tasks models.TaskCount `json:"tasks"`
+}
+
+// combinationsArgs are the arguments of find_combinations
+type combinationsArgs struct {
+	Points   int `json:"points" description:"Target point value"`
+	MaxTasks int `json:"max_tasks,omitempty" description:"Maximum total number of tasks in a combination"`
+}
+
+// planArgs are the arguments of plan_backlog
+type planArgs struct {
+	Items    []models.BacklogItem `json:"items" description:"Backlog items in priority order, highest first"`
+	Points   int                  `json:"points" description:"Target point value of the sprint"`
+	MaxTasks int                  `json:"max_tasks,omitempty" description:"Maximum number of items to select"`
+}
+
+// toolResult is the result of tools/call
+type toolResult struct {
+	Content           []textContent `json:"content"`
+	StructuredContent any           `json:"structuredContent,omitempty"`
+	IsError           bool          `json:"isError,omitempty"`
+}
+
+// textContent is a block of text in a tool result
+type textContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// newTools describes the tools offered by the server, with schemas for the server's scale
+func (s *Server) newTools() []tool {
+	sc := s.estimator.Scale()
+	maxTasks := fmt.Sprintf(" (default %d, at most %d)", min(s.estimator.MaxTasks(), MaxTasksLimit), MaxTasksLimit)
+
+	points := tool{
+		Name:         "calculate_points",
+		Title:        "Calculate sprint points",
+		Description:  fmt.Sprintf("Calculate the total points, task count and per-size breakdown of task counts on the %s scale.", sc),
+		InputSchema:  schemaFor(reflect.TypeFor[pointsArgs](), sc),
+		OutputSchema: schemaFor(reflect.TypeFor[models.SprintCapacity](), sc),
+		run:          s.calculatePoints,
+	}
+
+	combinations := tool{
+		Name:  "find_combinations",
+		Title: "Find task combinations",
+		Description: fmt.Sprintf("Find every combination of task sizes on the %s scale adding up to a point target, "+
+			"fewest tasks first.", sc),
+		InputSchema:  schemaFor(reflect.TypeFor[combinationsArgs](), sc),
+		OutputSchema: schemaFor(reflect.TypeFor[models.CombinationResult](), sc),
+		run:          s.findCombinations,
+	}
+	combinations.InputSchema.Properties["points"].Minimum = intPtr(1)
+	combinations.InputSchema.Properties["points"].Maximum = intPtr(MaxPointsLimit)
+	combinations.InputSchema.Properties["max_tasks"].Description += maxTasks
+	combinations.InputSchema.Properties["max_tasks"].Minimum = intPtr(1)
+	combinations.InputSchema.Properties["max_tasks"].Maximum = intPtr(MaxTasksLimit)
+
+	plan := tool{
+		Name:  "plan_backlog",
+		Title: "Plan a sprint from the backlog",
+		Description: fmt.Sprintf("Select the backlog items, in priority order and with their dependencies, that best fit a "+
+			"point target on the %s scale, and explain why the others are deferred.", sc),
+		InputSchema:  schemaFor(reflect.TypeFor[planArgs](), sc),
+		OutputSchema: schemaFor(reflect.TypeFor[models.PlanResult](), sc),
+		run:          s.planBacklog,
+	}
+	plan.InputSchema.Properties["points"].Minimum = intPtr(1)
+	plan.InputSchema.Properties["points"].Maximum = intPtr(MaxPointsLimit)
+	plan.InputSchema.Properties["items"].MaxItems = intPtr(MaxItemsLimit)
+	plan.InputSchema.Properties["max_tasks"].Description += maxTasks
+	plan.InputSchema.Properties["max_tasks"].Minimum = intPtr(1)
+	plan.InputSchema.Properties["max_tasks"].Maximum = intPtr(MaxTasksLimit)
+
+	return []tool{points, combinations, plan}
+}
+
+// callTool runs the tool named in params until ctx is done; failures of the tool itself are
+// reported in the result so the model can see and correct them
+func (s *Server) callTool(ctx context.Context, params json.RawMessage) (any, *rpcError) {
+	var call struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if err := json.Unmarshal(params, &call); err != nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: "invalid tools/call params: " + err.Error()}
+	}
+
+	for _, t := range s.tools {
+		if t.Name != call.Name {
+			continue
+		}
+
+		result, err := t.run(ctx, call.Arguments)
+		if err != nil {
+			return toolResult{Content: []textContent{{Type: "text", Text: err.Error()}}, IsError: true}, nil
+		}
+
+		text, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return nil, &rpcError{Code: codeInternalError, Message: fmt.Sprintf("encoding result: %v", err)}
+		}
+
+		return toolResult{Content: []textContent{{Type: "text", Text: string(text)}}, StructuredContent: result}, nil
+	}
+
+	return nil, &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("unknown tool: %q", call.Name)}
+}
+
+// calculatePoints calculates the sprint capacity of the task counts
+func (s *Server) calculatePoints(ctx context.Context, arguments json.RawMessage) (any, error) {
+	var args pointsArgs
+	if err := decodeArguments(arguments, &args); err != nil {
+		return nil, err
+	}
+	if args.Tasks == nil {
+		return nil, errors.New("tasks is required")
+	}
+
+	return s.estimator.Capacity(ctx, args.Tasks)
+}
+
+// findCombinations finds the task combinations adding up to the points
+func (s *Server) findCombinations(ctx context.Context, arguments json.RawMessage) (any, error) {
+	var args combinationsArgs
+	if err := decodeArguments(arguments, &args); err != nil {
+		return nil, err
+	}
+	if err := pointsArg(args.Points); err != nil {
+		return nil, err
+	}
+	maxTasks, err := s.maxTasksArg(args.MaxTasks)
+	if err != nil {
+		return nil, err
+	}
+
+	est, err := s.estimator.With(sizely.WithMaxTasks(maxTasks), sizely.WithCombinationLimit(MaxCombinationsLimit))
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := est.Combinations(ctx, args.Points)
+	if errors.Is(err, sizely.ErrTooManyCombinations) {
+		return nil, fmt.Errorf("more than %d combinations found, lower points or max_tasks", MaxCombinationsLimit)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// planBacklog selects the backlog items that best fit the points
+func (s *Server) planBacklog(ctx context.Context, arguments json.RawMessage) (any, error) {
+	var args planArgs
+	if err := decodeArguments(arguments, &args); err != nil {
+		return nil, err
+	}
+	if err := pointsArg(args.Points); err != nil {
+		return nil, err
+	}
+	if len(args.Items) > MaxItemsLimit {
+		return nil, fmt.Errorf("items must hold at most %d backlog items, got %d", MaxItemsLimit, len(args.Items))
+	}
+	maxTasks, err := s.maxTasksArg(args.MaxTasks)
+	if err != nil {
+		return nil, err
+	}
+
+	est, err := s.estimator.With(sizely.WithMaxTasks(maxTasks))
+	if err != nil {
+		return nil, err
+	}
+
+	return est.Plan(ctx, args.Items, args.Points)
+}
+
+// pointsArg checks the points argument of a search
+func pointsArg(points int) error {
+	switch {
+	case points <= 0:
+		return fmt.Errorf("points must be positive, got %d", points)
+	case points > MaxPointsLimit:
+		return fmt.Errorf("points must be at most %d, got %d", MaxPointsLimit, points)
+	default:
+		return nil
+	}
+}
+
+// maxTasksArg returns the max_tasks argument, or the server's default capped at MaxTasksLimit
+// when it is omitted
+func (s *Server) maxTasksArg(maxTasks int) (int, error) {
+	switch {
+	case maxTasks < 0:
+		return 0, fmt.Errorf("max_tasks must be positive, got %d", maxTasks)
+	case maxTasks > MaxTasksLimit:
+		return 0, fmt.Errorf("max_tasks must be at most %d", MaxTasksLimit)
+	case maxTasks == 0:
+		return min(s.estimator.MaxTasks(), MaxTasksLimit), nil
+	default:
+		return maxTasks, nil
+	}
+}
+
+// decodeArguments decodes the arguments of a tool call into v, rejecting unknown arguments
+func decodeArguments(arguments json.RawMessage, v any) error {
+	if len(arguments) == 0 {
+		arguments = json.RawMessage("{}")
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(arguments))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	return nil
+}
@@ -1,28 +1,37 @@
 package models
 
-// TShirtSizePoints represents the points values for each T-shirt size
-var TShirtSizePoints = map[string]int{
-	"XS": 1,
-	"S":  3,
-	"M":  5,
-	"L":  10,
+// TaskCount represents the count of tasks for each size, keyed by size name
+type TaskCount map[string]int
+
+// Total returns the total number of tasks across all sizes
+func (t TaskCount) Total() int {
+	total := 0
+	for _, count := range t {
+		total += count
+	}
+
+	return total
 }
 
-// TaskCount represents the count of each T-shirt size
-type TaskCount struct {
-	XS int `json:"xs" yaml:"xs"`
-	S  int `json:"s" yaml:"s"`
-	M  int `json:"m" yaml:"m"`
-	L  int `json:"l" yaml:"l"`
+// BacklogItem represents a single sized task, such as a ticket in the sprint backlog
+type BacklogItem struct {
+	ID    string `json:"id" yaml:"id"`
+	Title string `json:"title,omitempty" yaml:"title,omitempty"`
+	Size  string `json:"size" yaml:"size"`
+
+	// DependsOn lists the IDs of items that must be done before this one
+	DependsOn []string `json:"depends_on,omitempty" yaml:"depends_on,omitempty"`
 }
 
-// Combination represents a combination of T-shirt sizes with calculated points
+// Combination represents a combination of sizes with calculated points
 type Combination struct {
-	XS     int `json:"xs" yaml:"xs"`
-	S      int `json:"s" yaml:"s"`
-	M      int `json:"m" yaml:"m"`
-	L      int `json:"l" yaml:"l"`
-	Points int `json:"points" yaml:"points"`
+	Counts TaskCount `json:"counts" yaml:"counts"`
+	Points int       `json:"points" yaml:"points"`
+}
+
+// TotalTasks returns the number of tasks in the combination
+func (c Combination) TotalTasks() int {
+	return c.Counts.Total()
 }
 
 // TaskBreakdown represents a detailed breakdown of tasks by size
@@ -31,14 +40,46 @@ type TaskBreakdown struct {
 	Count  int    `json:"count" yaml:"count"`
 	Points int    `json:"points" yaml:"points"`
 	Total  int    `json:"total" yaml:"total"`
+
+	// Items lists the backlog items of this size when the capacity was calculated from a backlog
+	Items []BacklogItem `json:"items,omitempty" yaml:"items,omitempty"`
 }
 
 // SprintCapacity represents a complete sprint capacity calculation
 type SprintCapacity struct {
-	TotalPoints int
-	TotalTasks  int
+	TotalPoints int             `json:"total_points" yaml:"total_points"`
+	TotalTasks  int             `json:"total_tasks" yaml:"total_tasks"`
 	Breakdown   []TaskBreakdown `json:"breakdown" yaml:"breakdown"`
 	Tasks       TaskCount       `json:"tasks" yaml:"tasks"`
+
+	// Effort is the estimated time of the tasks when the scale defines hours per size
+	Effort *Effort `json:"effort,omitempty" yaml:"effort,omitempty"`
+}
+
+// Effort represents the PERT estimate, in hours, of the time needed for a set of tasks
+type Effort struct {
+	MinHours      float64 `json:"min_hours" yaml:"min_hours"`
+	ExpectedHours float64 `json:"expected_hours" yaml:"expected_hours"`
+	MaxHours      float64 `json:"max_hours" yaml:"max_hours"`
+	StdDevHours   float64 `json:"std_dev_hours" yaml:"std_dev_hours"`
+
+	// Unestimated lists the sizes with tasks but without an hour range, left out of the estimate
+	Unestimated []string `json:"unestimated,omitempty" yaml:"unestimated,omitempty"`
+}
+
+// Constraints represents per-size limits on the tasks of a combination
+type Constraints struct {
+	// Min is the fewest tasks of each size a combination must have
+	Min TaskCount `json:"min,omitempty" yaml:"min,omitempty"`
+	// Max is the most tasks of each size a combination may have
+	Max TaskCount `json:"max,omitempty" yaml:"max,omitempty"`
+	// Exclude lists the sizes a combination must not use
+	Exclude []string `json:"exclude,omitempty" yaml:"exclude,omitempty"`
+}
+
+// IsZero reports whether no constraint is set
+func (c Constraints) IsZero() bool {
+	return len(c.Min) == 0 && len(c.Max) == 0 && len(c.Exclude) == 0
 }
 
 // CombinationResult represents the result of reverse calculation
@@ -47,4 +88,140 @@ type CombinationResult struct {
 	MaxTasks     int           `json:"max_tasks" yaml:"max_tasks"`
 	Combinations []Combination `json:"combinations" yaml:"combinations"`
 	TotalFound   int           `json:"total_found" yaml:"total_found"`
+
+	// Constraints are the per-size limits the combinations respect, if any
+	Constraints *Constraints `json:"constraints,omitempty" yaml:"constraints,omitempty"`
+}
+
+// PlannedItem represents a backlog item considered by sprint planning
+type PlannedItem struct {
+	BacklogItem `yaml:",inline"`
+	Rank        int    `json:"rank" yaml:"rank"`
+	Points      int    `json:"points" yaml:"points"`
+	Reason      string `json:"reason,omitempty" yaml:"reason,omitempty"`
+
+	// Requires lists every direct and transitive prerequisite, in the order they must be done
+	Requires []string `json:"requires,omitempty" yaml:"requires,omitempty"`
+}
+
+// PlanResult represents the backlog items selected for a target point value
+type PlanResult struct {
+	TargetPoints   int           `json:"target_points" yaml:"target_points"`
+	MaxTasks       int           `json:"max_tasks" yaml:"max_tasks"`
+	SelectedPoints int           `json:"selected_points" yaml:"selected_points"`
+	Selected       []PlannedItem `json:"selected" yaml:"selected"`
+	Deferred       []PlannedItem `json:"deferred" yaml:"deferred"`
+}
+
+// Member represents a team member and their availability during the sprint
+type Member struct {
+	Name string `json:"name" yaml:"name"`
+
+	// Days is the number of days the member is available; nil means the team's sprint days
+	Days        *float64 `json:"days,omitempty" yaml:"days,omitempty"`
+	FocusFactor float64  `json:"focus_factor,omitempty" yaml:"focus_factor,omitempty"`
+}
+
+// Team represents the people working on a sprint and how much they historically deliver
+type Team struct {
+	Name         string   `json:"name,omitempty" yaml:"name,omitempty"`
+	PointsPerDay float64  `json:"points_per_day" yaml:"points_per_day"`
+	FocusFactor  float64  `json:"focus_factor,omitempty" yaml:"focus_factor,omitempty"`
+	SprintDays   float64  `json:"sprint_days,omitempty" yaml:"sprint_days,omitempty"`
+	Members      []Member `json:"members" yaml:"members"`
+}
+
+// MemberCapacity represents the points a single member can deliver in the sprint
+type MemberCapacity struct {
+	Name        string  `json:"name" yaml:"name"`
+	Days        float64 `json:"days" yaml:"days"`
+	FocusFactor float64 `json:"focus_factor" yaml:"focus_factor"`
+	Points      float64 `json:"points" yaml:"points"`
+}
+
+// Commitment describes how planned points compare with team capacity
+type Commitment string
+
+const (
+	// CommitmentUnder means the plan leaves a significant part of the capacity unused
+	CommitmentUnder Commitment = "under"
+	// CommitmentBalanced means the plan fits the capacity
+	CommitmentBalanced Commitment = "balanced"
+	// CommitmentOver means the plan exceeds the capacity
+	CommitmentOver Commitment = "over"
+)
+
+// TeamCapacity represents the team's deliverable points compared with the plan
+type TeamCapacity struct {
+	Team           string           `json:"team,omitempty" yaml:"team,omitempty"`
+	PointsPerDay   float64          `json:"points_per_day" yaml:"points_per_day"`
+	Members        []MemberCapacity `json:"members" yaml:"members"`
+	CapacityPoints float64          `json:"capacity_points" yaml:"capacity_points"`
+	PlannedPoints  int              `json:"planned_points" yaml:"planned_points"`
+	Utilization    float64          `json:"utilization" yaml:"utilization"`
+	Commitment     Commitment       `json:"commitment" yaml:"commitment"`
+}
+
+// SprintRecord represents the planned and completed work of a finished sprint
+type SprintRecord struct {
+	Sprint          string    `json:"sprint" yaml:"sprint"`
+	Start           string    `json:"start,omitempty" yaml:"start,omitempty"`
+	End             string    `json:"end,omitempty" yaml:"end,omitempty"`
+	Scale           string    `json:"scale,omitempty" yaml:"scale,omitempty"`
+	Planned         TaskCount `json:"planned" yaml:"planned"`
+	Completed       TaskCount `json:"completed" yaml:"completed"`
+	PlannedPoints   int       `json:"planned_points" yaml:"planned_points"`
+	CompletedPoints int       `json:"completed_points" yaml:"completed_points"`
+}
+
+// SizeVelocity represents the average planned and completed tasks of one size per sprint
+type SizeVelocity struct {
+	Size             string  `json:"size" yaml:"size"`
+	AveragePlanned   float64 `json:"average_planned" yaml:"average_planned"`
+	AverageCompleted float64 `json:"average_completed" yaml:"average_completed"`
+}
+
+// VelocityReport represents velocity statistics over the sprint history
+type VelocityReport struct {
+	Sprints        []SprintRecord `json:"sprints" yaml:"sprints"`
+	Average        float64        `json:"average" yaml:"average"`
+	Median         float64        `json:"median" yaml:"median"`
+	Window         int            `json:"window" yaml:"window"`
+	RollingAverage float64        `json:"rolling_average" yaml:"rolling_average"`
+	Trend          float64        `json:"trend" yaml:"trend"`
+	CompletionRate float64        `json:"completion_rate" yaml:"completion_rate"`
+	PerSize        []SizeVelocity `json:"per_size" yaml:"per_size"`
+}
+
+// ForecastPercentile represents the points reached in at least Confidence percent of simulated sprints
+type ForecastPercentile struct {
+	Confidence int `json:"confidence" yaml:"confidence"`
+	Points     int `json:"points" yaml:"points"`
+}
+
+// SprintForecast represents the simulated chance of completing the planned points in one sprint
+type SprintForecast struct {
+	PlannedPoints int                  `json:"planned_points" yaml:"planned_points"`
+	Trials        int                  `json:"trials" yaml:"trials"`
+	Sprints       int                  `json:"sprints" yaml:"sprints"`
+	Probability   float64              `json:"probability" yaml:"probability"`
+	Percentiles   []ForecastPercentile `json:"percentiles" yaml:"percentiles"`
+}
+
+// ReleasePercentile represents the sprints needed to finish the backlog in at least Confidence percent of simulations
+type ReleasePercentile struct {
+	Confidence int    `json:"confidence" yaml:"confidence"`
+	Sprints    int    `json:"sprints" yaml:"sprints"`
+	Finish     string `json:"finish" yaml:"finish"`
+}
+
+// ReleaseForecast represents the simulated number of sprints and dates needed to burn down a backlog
+type ReleaseForecast struct {
+	TotalPoints  int                 `json:"total_points" yaml:"total_points"`
+	TotalTasks   int                 `json:"total_tasks" yaml:"total_tasks"`
+	Start        string              `json:"start" yaml:"start"`
+	SprintLength int                 `json:"sprint_length_days" yaml:"sprint_length_days"`
+	Trials       int                 `json:"trials" yaml:"trials"`
+	Sprints      int                 `json:"history_sprints" yaml:"history_sprints"`
+	Percentiles  []ReleasePercentile `json:"percentiles" yaml:"percentiles"`
 }
@@ -0,0 +1,113 @@
+package render
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/gr1m0h/sizely/internal/config"
+	"github.com/gr1m0h/sizely/internal/models"
+	"github.com/gr1m0h/sizely/internal/scale"
+)
+
+// csvRenderer writes the main table of each result as comma-separated values with a header row
+type csvRenderer struct {
+	w     io.Writer
+	scale scale.Scale
+}
+
+// write writes the header and rows as CSV
+func (r *csvRenderer) write(header []string, rows [][]string) error {
+	cw := csv.NewWriter(r.w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+
+	return cw.Error()
+}
+
+// Capacity writes one row per size followed by a total row
+func (r *csvRenderer) Capacity(report CapacityReport) error {
+	capacity := report.Capacity
+
+	rows := make([][]string, 0, len(capacity.Breakdown)+1)
+	for _, b := range capacity.Breakdown {
+		rows = append(rows, []string{b.Size, strconv.Itoa(b.Points), strconv.Itoa(b.Count), strconv.Itoa(b.Total)})
+	}
+	rows = append(rows, []string{"Total", "", strconv.Itoa(capacity.TotalTasks), strconv.Itoa(capacity.TotalPoints)})
+
+	return r.write([]string{"size", "points", "tasks", "total_points"}, rows)
+}
+
+// Combinations writes one row per combination with a column per size, largest first
+func (r *csvRenderer) Combinations(result models.CombinationResult) error {
+	sizes := r.scale.Descending()
+
+	header := []string{"combination"}
+	for _, size := range sizes {
+		header = append(header, size.Name)
+	}
+	header = append(header, "tasks", "points")
+
+	rows := make([][]string, 0, len(result.Combinations))
+	for i, combo := range result.Combinations {
+		row := []string{strconv.Itoa(i + 1)}
+		for _, size := range sizes {
+			row = append(row, strconv.Itoa(combo.Counts[size.Name]))
+		}
+		rows = append(rows, append(row, strconv.Itoa(combo.TotalTasks()), strconv.Itoa(combo.Points)))
+	}
+
+	return r.write(header, rows)
+}
+
+// Plan writes the selected items followed by the deferred ones
+func (r *csvRenderer) Plan(result models.PlanResult) error {
+	items, status := planRows(result)
+
+	rows := make([][]string, len(items))
+	for i, item := range items {
+		rows[i] = []string{
+			strconv.Itoa(item.Rank), item.ID, item.Title, item.Size, strconv.Itoa(item.Points),
+			status[i], strings.Join(item.Requires, " "), item.Reason,
+		}
+	}
+
+	return r.write([]string{"rank", "id", "title", "size", "points", "status", "requires", "reason"}, rows)
+}
+
+// Velocity writes one row per recorded sprint
+func (r *csvRenderer) Velocity(report models.VelocityReport) error {
+	rows := make([][]string, len(report.Sprints))
+	for i, record := range report.Sprints {
+		rows[i] = []string{record.Sprint, record.Start, record.End, strconv.Itoa(record.PlannedPoints), strconv.Itoa(record.CompletedPoints)}
+	}
+
+	return r.write([]string{"sprint", "start", "end", "planned_points", "completed_points"}, rows)
+}
+
+// Release writes one row per confidence level
+func (r *csvRenderer) Release(forecast models.ReleaseForecast) error {
+	rows := make([][]string, len(forecast.Percentiles))
+	for i, pct := range forecast.Percentiles {
+		rows[i] = []string{strconv.Itoa(pct.Confidence), strconv.Itoa(pct.Sprints), pct.Finish}
+	}
+
+	return r.write([]string{"confidence", "sprints", "finish"}, rows)
+}
+
+// Config writes one row per setting
+func (r *csvRenderer) Config(cfg *config.Config) error {
+	doc := newConfigDocument(cfg)
+
+	rows := make([][]string, len(doc.Settings))
+	for i, setting := range doc.Settings {
+		rows[i] = []string{setting.Key, setting.Value, setting.Source}
+	}
+
+	return r.write([]string{"key", "value", "source"}, rows)
+}
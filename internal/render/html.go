@@ -0,0 +1,445 @@
+package render
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/gr1m0h/sizely/internal/calendar"
+	"github.com/gr1m0h/sizely/internal/config"
+	"github.com/gr1m0h/sizely/internal/models"
+	"github.com/gr1m0h/sizely/internal/scale"
+)
+
+// htmlRenderer writes a self-contained HTML page with inline SVG charts, for sharing with stakeholders
+type htmlRenderer struct {
+	w      io.Writer
+	scale  scale.Scale
+	advice config.Advice
+}
+
+// palette colors the sizes of a scale in order, repeating for longer scales
+var palette = []string{"#4e79a7", "#f28e2b", "#e15759", "#76b7b2", "#59a14f", "#edc948", "#b07aa1", "#ff9da7", "#9c755f", "#bab0ac"}
+
+// htmlStyle is the stylesheet embedded in every page
+const htmlStyle = `body{font-family:-apple-system,"Segoe UI",Helvetica,Arial,sans-serif;color:#24292f;max-width:960px;margin:2em auto;padding:0 1em}
+h1{border-bottom:1px solid #d0d7de;padding-bottom:.3em}
+table{border-collapse:collapse;margin:1em 0}
+th,td{border:1px solid #d0d7de;padding:.4em .8em;text-align:left}
+th{background:#f6f8fa}
+td.num{text-align:right}
+.charts{display:flex;flex-wrap:wrap;gap:2em}
+figure{margin:0}
+figcaption{font-weight:600;margin-bottom:.5em}
+svg text{font-size:12px;fill:#24292f}
+.summary{font-size:1.2em}
+.advice{margin:.3em 0 0;padding-left:1.2em;color:#57606a}
+footer{margin-top:3em;color:#57606a;font-size:.9em}
+`
+
+// chartValue is one bar of a bar chart or one slice of a pie chart
+type chartValue struct {
+	Label string
+	Value float64
+	Text  string
+	Color string
+}
+
+// esc escapes text for HTML content and attributes
+func esc(s string) string {
+	return html.EscapeString(s)
+}
+
+// page writes the document around the body, titled title
+func page(p *printer, title string, body func()) {
+	p.printf("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\">\n")
+	p.printf("<meta name=\"viewport\" content=\"width=device-width, initial-scale=1\">\n")
+	p.printf("<title>%s</title>\n<style>\n%s</style>\n</head>\n<body>\n", esc(title), htmlStyle)
+	p.printf("<h1>%s</h1>\n", esc(title))
+	body()
+	p.printf("<footer>Generated by sizely</footer>\n</body>\n</html>\n")
+}
+
+// htmlTable writes an HTML table; cells that hold numbers are right-aligned
+func htmlTable(p *printer, header []string, rows [][]string) {
+	p.printf("<table>\n<thead><tr>")
+	for _, cell := range header {
+		p.printf("<th>%s</th>", esc(cell))
+	}
+	p.printf("</tr></thead>\n<tbody>\n")
+	for _, row := range rows {
+		p.printf("<tr>")
+		for _, cell := range row {
+			if isNumber(cell) {
+				p.printf("<td class=\"num\">%s</td>", esc(cell))
+			} else {
+				p.printf("<td>%s</td>", esc(cell))
+			}
+		}
+		p.printf("</tr>\n")
+	}
+	p.printf("</tbody>\n</table>\n")
+}
+
+// isNumber reports whether a table cell holds a plain number
+func isNumber(s string) bool {
+	var f float64
+	_, err := fmt.Sscanf(s, "%g", &f)
+
+	return err == nil && strings.TrimLeft(s, "+-0123456789.") == ""
+}
+
+// barChart writes a horizontal bar chart scaled to the largest value
+func barChart(p *printer, caption string, values []chartValue) {
+	const (
+		labelWidth = 80
+		barWidth   = 320
+		textWidth  = 120
+		rowHeight  = 28
+	)
+
+	largest := 0.0
+	for _, v := range values {
+		largest = math.Max(largest, v.Value)
+	}
+
+	height := rowHeight*len(values) + 8
+	p.printf("<figure>\n<figcaption>%s</figcaption>\n", esc(caption))
+	p.printf("<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\" role=\"img\" aria-label=\"%s\">\n",
+		labelWidth+barWidth+textWidth, height, labelWidth+barWidth+textWidth, height, esc(caption))
+	for i, v := range values {
+		y := 4 + i*rowHeight
+		width := 0.0
+		if largest > 0 {
+			width = v.Value / largest * barWidth
+		}
+		p.printf("<text x=\"%d\" y=\"%d\" text-anchor=\"end\">%s</text>", labelWidth-8, y+rowHeight/2+4, esc(v.Label))
+		p.printf("<rect x=\"%d\" y=\"%d\" width=\"%.1f\" height=\"%d\" fill=\"%s\"><title>%s: %s</title></rect>",
+			labelWidth, y+4, width, rowHeight-8, v.Color, esc(v.Label), esc(v.Text))
+		p.printf("<text x=\"%.1f\" y=\"%d\">%s</text>\n", float64(labelWidth)+width+6, y+rowHeight/2+4, esc(v.Text))
+	}
+	p.printf("</svg>\n</figure>\n")
+}
+
+// pieChart writes a pie chart of the values' shares with a legend; values of zero are left out
+func pieChart(p *printer, caption string, values []chartValue) {
+	const (
+		radius = 100
+		cx     = 110
+		cy     = 110
+		legend = 160
+	)
+
+	total := 0.0
+	var slices []chartValue
+	for _, v := range values {
+		if v.Value > 0 {
+			total += v.Value
+			slices = append(slices, v)
+		}
+	}
+
+	height := max(2*cy, 20*len(slices)+20)
+	p.printf("<figure>\n<figcaption>%s</figcaption>\n", esc(caption))
+	p.printf("<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\" role=\"img\" aria-label=\"%s\">\n",
+		2*cx+legend, height, 2*cx+legend, height, esc(caption))
+
+	angle := -math.Pi / 2
+	for i, v := range slices {
+		share := v.Value / total
+		title := fmt.Sprintf("%s: %s (%.0f%%)", v.Label, v.Text, share*100)
+		if len(slices) == 1 {
+			p.printf("<circle cx=\"%d\" cy=\"%d\" r=\"%d\" fill=\"%s\"><title>%s</title></circle>\n", cx, cy, radius, v.Color, esc(title))
+		} else {
+			end := angle + share*2*math.Pi
+			largeArc := 0
+			if share > 0.5 {
+				largeArc = 1
+			}
+			p.printf("<path d=\"M%d %d L%.2f %.2f A%d %d 0 %d 1 %.2f %.2f Z\" fill=\"%s\" stroke=\"#fff\"><title>%s</title></path>\n",
+				cx, cy, cx+radius*math.Cos(angle), cy+radius*math.Sin(angle), radius, radius, largeArc,
+				cx+radius*math.Cos(end), cy+radius*math.Sin(end), v.Color, esc(title))
+			angle = end
+		}
+
+		y := 20 + i*20
+		p.printf("<rect x=\"%d\" y=\"%d\" width=\"12\" height=\"12\" fill=\"%s\"/><text x=\"%d\" y=\"%d\">%s %.0f%%</text>\n",
+			2*cx+10, y-10, v.Color, 2*cx+28, y, esc(v.Label), share*100)
+	}
+	p.printf("</svg>\n</figure>\n")
+}
+
+// sizeColor returns the chart color of the size at index i of the scale
+func sizeColor(i int) string {
+	return palette[i%len(palette)]
+}
+
+// Capacity writes the summary, the size charts and the breakdown followed by the sections that apply
+func (r *htmlRenderer) Capacity(report CapacityReport) error {
+	p := &printer{w: r.w}
+	capacity := report.Capacity
+
+	page(p, "Sprint Capacity", func() {
+		p.printf("<p class=\"summary\"><strong>%d tasks</strong> = <strong>%d points</strong></p>\n", capacity.TotalTasks, capacity.TotalPoints)
+
+		bars := make([]chartValue, len(capacity.Breakdown))
+		slices := make([]chartValue, len(capacity.Breakdown))
+		for i, b := range capacity.Breakdown {
+			bars[i] = chartValue{Label: b.Size, Value: float64(b.Count), Text: fmt.Sprintf("%d × %dpt", b.Count, b.Points), Color: sizeColor(i)}
+			slices[i] = chartValue{Label: b.Size, Value: float64(b.Total), Text: fmt.Sprintf("%d points", b.Total), Color: sizeColor(i)}
+		}
+		p.printf("<div class=\"charts\">\n")
+		barChart(p, "Tasks per size", bars)
+		if capacity.TotalPoints > 0 {
+			pieChart(p, "Points distribution", slices)
+		}
+		p.printf("</div>\n")
+
+		p.printf("<h2>Breakdown</h2>\n")
+		rows := make([][]string, 0, len(capacity.Breakdown)+1)
+		for _, b := range capacity.Breakdown {
+			rows = append(rows, []string{b.Size, fmt.Sprint(b.Points), fmt.Sprint(b.Count), fmt.Sprint(b.Total)})
+		}
+		rows = append(rows, []string{"Total", "", fmt.Sprint(capacity.TotalTasks), fmt.Sprint(capacity.TotalPoints)})
+		htmlTable(p, []string{"Size", "Points", "Tasks", "Total"}, rows)
+
+		if effort := capacity.Effort; effort != nil {
+			p.printf("<h2>Estimated Effort (PERT)</h2>\n")
+			htmlTable(p, []string{"", "Hours", "Days"}, [][]string{
+				{"Min", fmt.Sprintf("%.1f", effort.MinHours), fmt.Sprintf("%.1f", effort.MinHours/hoursPerDay)},
+				{"Expected", fmt.Sprintf("%.1f ± %.1f", effort.ExpectedHours, effort.StdDevHours), fmt.Sprintf("%.1f", effort.ExpectedHours/hoursPerDay)},
+				{"Max", fmt.Sprintf("%.1f", effort.MaxHours), fmt.Sprintf("%.1f", effort.MaxHours/hoursPerDay)},
+			})
+			if len(effort.Unestimated) > 0 {
+				p.printf("<p>No hour range for %s; those tasks are not included.</p>\n", esc(strings.Join(effort.Unestimated, ", ")))
+			}
+		}
+
+		if hasItems(capacity.Breakdown) {
+			p.printf("<h2>Tasks by Size</h2>\n")
+			var rows [][]string
+			for _, b := range capacity.Breakdown {
+				for _, item := range b.Items {
+					rows = append(rows, []string{b.Size, item.ID, item.Title})
+				}
+			}
+			htmlTable(p, []string{"Size", "ID", "Title"}, rows)
+		}
+
+		if report.Availability != nil {
+			r.availability(p, *report.Availability)
+		}
+		if report.Team != nil {
+			r.team(p, *report.Team)
+		}
+		if forecast := report.Forecast; forecast != nil {
+			p.printf("<h2>Sprint Forecast</h2>\n")
+			p.printf("<p>Chance to complete %d points: <strong>%.0f%%</strong> (%d simulations from %d past sprints)</p>\n",
+				forecast.PlannedPoints, forecast.Probability*100, forecast.Trials, forecast.Sprints)
+			rows := make([][]string, len(forecast.Percentiles))
+			for i, pct := range forecast.Percentiles {
+				rows[i] = []string{fmt.Sprintf("P%d", pct.Confidence), fmt.Sprint(pct.Points)}
+			}
+			htmlTable(p, []string{"Confidence", "Points"}, rows)
+		}
+	})
+
+	return p.err
+}
+
+// availability writes the sprint's working days and each member's days off
+func (r *htmlRenderer) availability(p *printer, availability calendar.Availability) {
+	p.printf("<h2>Sprint Calendar: %s to %s</h2>\n", availability.Start.Format(calendar.DateLayout), availability.End.Format(calendar.DateLayout))
+	p.printf("<p>%d weekdays, %d working days", availability.WeekDays, availability.WorkingDays)
+	for i, holiday := range availability.Holidays {
+		if i == 0 {
+			p.printf("; holidays: ")
+		} else {
+			p.printf(", ")
+		}
+		p.printf("%s %s", holiday.Date.Format(calendar.DateLayout), esc(holiday.Name))
+	}
+	p.printf("</p>\n")
+
+	rows := make([][]string, len(availability.Members))
+	for i, member := range availability.Members {
+		rows[i] = []string{member.Name, fmt.Sprint(member.Days), fmt.Sprint(member.PTODays)}
+	}
+	htmlTable(p, []string{"Member", "Days", "PTO"}, rows)
+}
+
+// team writes each member's capacity and how the plan compares with the team's total
+func (r *htmlRenderer) team(p *printer, capacity models.TeamCapacity) {
+	if capacity.Team == "" {
+		p.printf("<h2>Team Capacity</h2>\n")
+	} else {
+		p.printf("<h2>Team Capacity: %s</h2>\n", esc(capacity.Team))
+	}
+
+	rows := make([][]string, len(capacity.Members))
+	for i, member := range capacity.Members {
+		rows[i] = []string{member.Name, fmt.Sprintf("%.1f", member.Days), fmt.Sprintf("%.2f", member.FocusFactor), fmt.Sprintf("%.1f", member.Points)}
+	}
+	htmlTable(p, []string{"Member", "Days", "Focus", "Points"}, rows)
+
+	p.printf("<p>Planned %d of %.1f points (%.0f%% of capacity): <strong>%s</strong></p>\n",
+		capacity.PlannedPoints, capacity.CapacityPoints, capacity.Utilization*100, esc(string(capacity.Commitment)))
+}
+
+// Combinations writes each combination with a bar of its size mix and its advice
+func (r *htmlRenderer) Combinations(result models.CombinationResult) error {
+	p := &printer{w: r.w}
+
+	page(p, fmt.Sprintf("Combinations for %d points (max %d tasks)", result.TargetPoints, result.MaxTasks), func() {
+		if result.Constraints != nil {
+			p.printf("<p>Constraints: %s</p>\n", esc(constraintsLabel(r.scale, result.Constraints)))
+		}
+		if result.TotalFound == 0 {
+			p.printf("<p>No combinations found.</p>\n")
+			return
+		}
+
+		p.printf("<p class=\"summary\">Found <strong>%d</strong> combinations</p>\n", result.TotalFound)
+		p.printf("<ol>\n")
+		for _, combo := range result.Combinations {
+			p.printf("<li><strong>%s</strong> — %d tasks, %d points\n", esc(combinationLabel(r.scale, combo)), combo.TotalTasks(), combo.Points)
+			r.mix(p, combo)
+			if advice := combinationAdvice(r.scale, r.advice, combo); len(advice) > 0 {
+				p.printf("<ul class=\"advice\">")
+				for _, tip := range advice {
+					p.printf("<li>%s</li>", esc(strings.Join(strings.Fields(tip), " ")))
+				}
+				p.printf("</ul>\n")
+			}
+			p.printf("</li>\n")
+		}
+		p.printf("</ol>\n")
+	})
+
+	return p.err
+}
+
+// mix writes a stacked bar of the points each size contributes to a combination
+func (r *htmlRenderer) mix(p *printer, combo models.Combination) {
+	const width, height = 320, 14
+
+	p.printf("<br><svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" role=\"img\" aria-label=\"points per size\">", width, height)
+	x := 0.0
+	for i, size := range r.scale.Sizes {
+		points := combo.Counts[size.Name] * size.Points
+		if points == 0 || combo.Points == 0 {
+			continue
+		}
+		w := float64(points) / float64(combo.Points) * width
+		p.printf("<rect x=\"%.1f\" y=\"0\" width=\"%.1f\" height=\"%d\" fill=\"%s\"><title>%s×%d: %d points</title></rect>",
+			x, w, height, sizeColor(i), esc(size.Name), combo.Counts[size.Name], points)
+		x += w
+	}
+	p.printf("</svg>\n")
+}
+
+// Plan writes the selected and deferred items as one table
+func (r *htmlRenderer) Plan(result models.PlanResult) error {
+	p := &printer{w: r.w}
+
+	page(p, fmt.Sprintf("Sprint Plan for %d points (max %d tasks)", result.TargetPoints, result.MaxTasks), func() {
+		p.printf("<p class=\"summary\">Selected %d tasks = %d of %d points</p>\n", len(result.Selected), result.SelectedPoints, result.TargetPoints)
+
+		items, status := planRows(result)
+		rows := make([][]string, len(items))
+		for i, item := range items {
+			var notes []string
+			if len(item.Requires) > 0 {
+				notes = append(notes, "requires "+strings.Join(item.Requires, " → "))
+			}
+			if item.Reason != "" {
+				notes = append(notes, item.Reason)
+			}
+			rows[i] = []string{fmt.Sprint(item.Rank), item.ID, item.Title, item.Size, fmt.Sprint(item.Points), status[i], strings.Join(notes, "; ")}
+		}
+		htmlTable(p, []string{"Rank", "ID", "Title", "Size", "Points", "Status", "Notes"}, rows)
+	})
+
+	return p.err
+}
+
+// Velocity writes a chart of the completed points per sprint and the velocity statistics
+func (r *htmlRenderer) Velocity(report models.VelocityReport) error {
+	p := &printer{w: r.w}
+
+	page(p, fmt.Sprintf("Velocity over %d sprints", len(report.Sprints)), func() {
+		bars := make([]chartValue, len(report.Sprints))
+		for i, record := range report.Sprints {
+			bars[i] = chartValue{
+				Label: record.Sprint,
+				Value: float64(record.CompletedPoints),
+				Text:  fmt.Sprintf("%d of %d points", record.CompletedPoints, record.PlannedPoints),
+				Color: palette[0],
+			}
+		}
+		barChart(p, "Completed points", bars)
+
+		p.printf("<ul>\n")
+		p.printf("<li>Average: %.1f points</li>\n", report.Average)
+		p.printf("<li>Median: %.1f points</li>\n", report.Median)
+		p.printf("<li>Last %d sprints: %.1f points</li>\n", report.Window, report.RollingAverage)
+		p.printf("<li>Trend: %+.1f points per sprint</li>\n", report.Trend)
+		p.printf("<li>Completion: %.0f%% of planned points</li>\n", report.CompletionRate*100)
+		p.printf("</ul>\n")
+
+		if len(report.PerSize) > 0 {
+			p.printf("<h2>Tasks per Sprint by Size</h2>\n")
+			rows := make([][]string, len(report.PerSize))
+			for i, size := range report.PerSize {
+				rows[i] = []string{size.Size, fmt.Sprintf("%.1f", size.AveragePlanned), fmt.Sprintf("%.1f", size.AverageCompleted)}
+			}
+			htmlTable(p, []string{"Size", "Planned", "Completed"}, rows)
+		}
+	})
+
+	return p.err
+}
+
+// Release writes the sprints and finish dates per confidence level
+func (r *htmlRenderer) Release(forecast models.ReleaseForecast) error {
+	p := &printer{w: r.w}
+
+	page(p, fmt.Sprintf("Release Forecast: %d tasks = %d points", forecast.TotalTasks, forecast.TotalPoints), func() {
+		p.printf("<p>Starting %s with %d-day sprints (%d simulations from %d past sprints)</p>\n",
+			esc(forecast.Start), forecast.SprintLength, forecast.Trials, forecast.Sprints)
+
+		rows := make([][]string, len(forecast.Percentiles))
+		for i, pct := range forecast.Percentiles {
+			rows[i] = []string{fmt.Sprintf("P%d", pct.Confidence), fmt.Sprint(pct.Sprints), pct.Finish}
+		}
+		htmlTable(p, []string{"Confidence", "Sprints", "Finish"}, rows)
+	})
+
+	return p.err
+}
+
+// Config writes the configuration settings and the config files they came from
+func (r *htmlRenderer) Config(cfg *config.Config) error {
+	p := &printer{w: r.w}
+	doc := newConfigDocument(cfg)
+
+	page(p, "Effective Configuration", func() {
+		rows := make([][]string, len(doc.Settings))
+		for i, setting := range doc.Settings {
+			rows[i] = []string{setting.Key, setting.Value, setting.Source}
+		}
+		htmlTable(p, []string{"Key", "Value", "Source"}, rows)
+
+		if len(doc.Files) > 0 {
+			p.printf("<p>Config files (lowest precedence first):</p>\n<ul>\n")
+			for _, file := range doc.Files {
+				p.printf("<li>%s</li>\n", esc(file))
+			}
+			p.printf("</ul>\n")
+		}
+	})
+
+	return p.err
+}
@@ -0,0 +1,251 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gr1m0h/sizely/internal/calendar"
+	"github.com/gr1m0h/sizely/internal/config"
+	"github.com/gr1m0h/sizely/internal/models"
+	"github.com/gr1m0h/sizely/internal/scale"
+)
+
+// markdownRenderer writes headings and GitHub-flavored tables, ready to paste into wiki pages
+type markdownRenderer struct {
+	w      io.Writer
+	scale  scale.Scale
+	advice config.Advice
+}
+
+// table writes a Markdown table; cells are escaped so they cannot break the row
+func table(p *printer, header []string, rows [][]string) {
+	p.printf("| %s |\n", strings.Join(header, " | "))
+	p.printf("|%s\n", strings.Repeat(" --- |", len(header)))
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = escapeCell(cell)
+		}
+		p.printf("| %s |\n", strings.Join(cells, " | "))
+	}
+	p.printf("\n")
+}
+
+// escapeCell escapes pipes and flattens line breaks in a table cell
+func escapeCell(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+}
+
+// Capacity writes the size breakdown followed by the effort, backlog items, team and forecast sections that apply
+func (r *markdownRenderer) Capacity(report CapacityReport) error {
+	p := &printer{w: r.w}
+	capacity := report.Capacity
+
+	p.printf("## Sprint Capacity\n\n")
+	rows := make([][]string, 0, len(capacity.Breakdown)+1)
+	for _, b := range capacity.Breakdown {
+		rows = append(rows, []string{b.Size, fmt.Sprint(b.Points), fmt.Sprint(b.Count), fmt.Sprint(b.Total)})
+	}
+	rows = append(rows, []string{"**Total**", "", fmt.Sprintf("**%d**", capacity.TotalTasks), fmt.Sprintf("**%d**", capacity.TotalPoints)})
+	table(p, []string{"Size", "Points", "Tasks", "Total"}, rows)
+
+	if effort := capacity.Effort; effort != nil {
+		p.printf("### Estimated Effort (PERT)\n\n")
+		table(p, []string{"", "Hours", "Days"}, [][]string{
+			{"Min", fmt.Sprintf("%.1f", effort.MinHours), fmt.Sprintf("%.1f", effort.MinHours/hoursPerDay)},
+			{"Expected", fmt.Sprintf("%.1f ± %.1f", effort.ExpectedHours, effort.StdDevHours), fmt.Sprintf("%.1f", effort.ExpectedHours/hoursPerDay)},
+			{"Max", fmt.Sprintf("%.1f", effort.MaxHours), fmt.Sprintf("%.1f", effort.MaxHours/hoursPerDay)},
+		})
+		if len(effort.Unestimated) > 0 {
+			p.printf("> No hour range for %s; those tasks are not included.\n\n", strings.Join(effort.Unestimated, ", "))
+		}
+	}
+
+	if hasItems(capacity.Breakdown) {
+		p.printf("### Tasks by Size\n\n")
+		var rows [][]string
+		for _, b := range capacity.Breakdown {
+			for _, item := range b.Items {
+				rows = append(rows, []string{b.Size, item.ID, item.Title})
+			}
+		}
+		table(p, []string{"Size", "ID", "Title"}, rows)
+	}
+
+	if report.Availability != nil {
+		r.availability(p, *report.Availability)
+	}
+	if report.Team != nil {
+		r.team(p, *report.Team)
+	}
+	if forecast := report.Forecast; forecast != nil {
+		p.printf("### Sprint Forecast\n\n")
+		p.printf("Chance to complete %d points: **%.0f%%** (%d simulations from %d past sprints)\n\n",
+			forecast.PlannedPoints, forecast.Probability*100, forecast.Trials, forecast.Sprints)
+		rows := make([][]string, len(forecast.Percentiles))
+		for i, pct := range forecast.Percentiles {
+			rows[i] = []string{fmt.Sprintf("P%d", pct.Confidence), fmt.Sprint(pct.Points)}
+		}
+		table(p, []string{"Confidence", "Points"}, rows)
+	}
+
+	return p.err
+}
+
+// availability writes the sprint's working days and each member's days off
+func (r *markdownRenderer) availability(p *printer, availability calendar.Availability) {
+	p.printf("### Sprint Calendar: %s to %s\n\n", availability.Start.Format(calendar.DateLayout), availability.End.Format(calendar.DateLayout))
+	p.printf("%d weekdays, %d working days", availability.WeekDays, availability.WorkingDays)
+	for i, holiday := range availability.Holidays {
+		if i == 0 {
+			p.printf("; holidays: ")
+		} else {
+			p.printf(", ")
+		}
+		p.printf("%s %s", holiday.Date.Format(calendar.DateLayout), holiday.Name)
+	}
+	p.printf("\n\n")
+
+	rows := make([][]string, len(availability.Members))
+	for i, member := range availability.Members {
+		rows[i] = []string{member.Name, fmt.Sprint(member.Days), fmt.Sprint(member.PTODays)}
+	}
+	table(p, []string{"Member", "Days", "PTO"}, rows)
+}
+
+// team writes each member's capacity and how the plan compares with the team's total
+func (r *markdownRenderer) team(p *printer, capacity models.TeamCapacity) {
+	if capacity.Team == "" {
+		p.printf("### Team Capacity\n\n")
+	} else {
+		p.printf("### Team Capacity: %s\n\n", capacity.Team)
+	}
+
+	rows := make([][]string, len(capacity.Members))
+	for i, member := range capacity.Members {
+		rows[i] = []string{member.Name, fmt.Sprintf("%.1f", member.Days), fmt.Sprintf("%.2f", member.FocusFactor), fmt.Sprintf("%.1f", member.Points)}
+	}
+	table(p, []string{"Member", "Days", "Focus", "Points"}, rows)
+
+	p.printf("Planned %d of %.1f points (%.0f%% of capacity): %s\n\n",
+		capacity.PlannedPoints, capacity.CapacityPoints, capacity.Utilization*100, capacity.Commitment)
+}
+
+// Combinations writes one table row per combination with its advice
+func (r *markdownRenderer) Combinations(result models.CombinationResult) error {
+	p := &printer{w: r.w}
+
+	p.printf("## Combinations for %d points (max %d tasks)\n\n", result.TargetPoints, result.MaxTasks)
+	if result.Constraints != nil {
+		p.printf("Constraints: %s\n\n", constraintsLabel(r.scale, result.Constraints))
+	}
+	if result.TotalFound == 0 {
+		p.printf("No combinations found.\n")
+		return p.err
+	}
+
+	rows := make([][]string, len(result.Combinations))
+	for i, combo := range result.Combinations {
+		advice := combinationAdvice(r.scale, r.advice, combo)
+		for j, tip := range advice {
+			advice[j] = strings.Join(strings.Fields(tip), " ")
+		}
+		rows[i] = []string{fmt.Sprint(i + 1), combinationLabel(r.scale, combo), fmt.Sprint(combo.TotalTasks()), fmt.Sprint(combo.Points), strings.Join(advice, "<br>")}
+	}
+	table(p, []string{"#", "Combination", "Tasks", "Points", "Advice"}, rows)
+
+	return p.err
+}
+
+// Plan writes the selected and deferred items as one table
+func (r *markdownRenderer) Plan(result models.PlanResult) error {
+	p := &printer{w: r.w}
+
+	p.printf("## Sprint Plan for %d points (max %d tasks)\n\n", result.TargetPoints, result.MaxTasks)
+	p.printf("Selected %d tasks = %d of %d points\n\n", len(result.Selected), result.SelectedPoints, result.TargetPoints)
+
+	items, status := planRows(result)
+	rows := make([][]string, len(items))
+	for i, item := range items {
+		var notes []string
+		if len(item.Requires) > 0 {
+			notes = append(notes, "requires "+strings.Join(item.Requires, " → "))
+		}
+		if item.Reason != "" {
+			notes = append(notes, item.Reason)
+		}
+		rows[i] = []string{fmt.Sprint(item.Rank), item.ID, item.Title, item.Size, fmt.Sprint(item.Points), status[i], strings.Join(notes, "; ")}
+	}
+	table(p, []string{"Rank", "ID", "Title", "Size", "Points", "Status", "Notes"}, rows)
+
+	return p.err
+}
+
+// Velocity writes the recorded sprints and the velocity statistics
+func (r *markdownRenderer) Velocity(report models.VelocityReport) error {
+	p := &printer{w: r.w}
+
+	p.printf("## Velocity over %d sprints\n\n", len(report.Sprints))
+	rows := make([][]string, len(report.Sprints))
+	for i, record := range report.Sprints {
+		rows[i] = []string{record.Sprint, fmt.Sprint(record.PlannedPoints), fmt.Sprint(record.CompletedPoints)}
+	}
+	table(p, []string{"Sprint", "Planned", "Completed"}, rows)
+
+	p.printf("- Average: %.1f points\n", report.Average)
+	p.printf("- Median: %.1f points\n", report.Median)
+	p.printf("- Last %d sprints: %.1f points\n", report.Window, report.RollingAverage)
+	p.printf("- Trend: %+.1f points per sprint\n", report.Trend)
+	p.printf("- Completion: %.0f%% of planned points\n\n", report.CompletionRate*100)
+
+	if len(report.PerSize) > 0 {
+		p.printf("### Tasks per Sprint by Size\n\n")
+		rows := make([][]string, len(report.PerSize))
+		for i, size := range report.PerSize {
+			rows[i] = []string{size.Size, fmt.Sprintf("%.1f", size.AveragePlanned), fmt.Sprintf("%.1f", size.AverageCompleted)}
+		}
+		table(p, []string{"Size", "Planned", "Completed"}, rows)
+	}
+
+	return p.err
+}
+
+// Release writes the sprints and finish dates per confidence level
+func (r *markdownRenderer) Release(forecast models.ReleaseForecast) error {
+	p := &printer{w: r.w}
+
+	p.printf("## Release Forecast: %d tasks = %d points\n\n", forecast.TotalTasks, forecast.TotalPoints)
+	p.printf("Starting %s with %d-day sprints (%d simulations from %d past sprints)\n\n",
+		forecast.Start, forecast.SprintLength, forecast.Trials, forecast.Sprints)
+
+	rows := make([][]string, len(forecast.Percentiles))
+	for i, pct := range forecast.Percentiles {
+		rows[i] = []string{fmt.Sprintf("P%d", pct.Confidence), fmt.Sprint(pct.Sprints), pct.Finish}
+	}
+	table(p, []string{"Confidence", "Sprints", "Finish"}, rows)
+
+	return p.err
+}
+
+// Config writes the configuration settings and the config files they came from
+func (r *markdownRenderer) Config(cfg *config.Config) error {
+	p := &printer{w: r.w}
+	doc := newConfigDocument(cfg)
+
+	p.printf("## Effective Configuration\n\n")
+	rows := make([][]string, len(doc.Settings))
+	for i, setting := range doc.Settings {
+		rows[i] = []string{setting.Key, setting.Value, setting.Source}
+	}
+	table(p, []string{"Key", "Value", "Source"}, rows)
+
+	if len(doc.Files) > 0 {
+		p.printf("Config files (lowest precedence first):\n\n")
+		for _, file := range doc.Files {
+			p.printf("- %s\n", file)
+		}
+	}
+
+	return p.err
+}
@@ -0,0 +1,232 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/gr1m0h/sizely/internal/calendar"
+	"github.com/gr1m0h/sizely/internal/config"
+	"github.com/gr1m0h/sizely/internal/models"
+	"github.com/gr1m0h/sizely/internal/scale"
+)
+
+// Output formats accepted by New
+const (
+	FormatText     = "text"
+	FormatJSON     = "json"
+	FormatYAML     = "yaml"
+	FormatCSV      = "csv"
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+)
+
+// Renderer writes the results of the sizely commands in one output format
+type Renderer interface {
+	// Capacity renders the result of the points command
+	Capacity(report CapacityReport) error
+	// Combinations renders the result of the tasks command
+	Combinations(result models.CombinationResult) error
+	// Plan renders the result of the plan command
+	Plan(result models.PlanResult) error
+	// Velocity renders the result of the velocity command
+	Velocity(report models.VelocityReport) error
+	// Release renders the result of the forecast command
+	Release(forecast models.ReleaseForecast) error
+	// Config renders the effective configuration and where each value came from
+	Config(cfg *config.Config) error
+}
+
+// CapacityReport represents everything the points command reports about a sprint
+type CapacityReport struct {
+	Capacity     models.SprintCapacity  `json:"capacity" yaml:"capacity"`
+	Availability *calendar.Availability `json:"availability,omitempty" yaml:"availability,omitempty"`
+	Team         *models.TeamCapacity   `json:"team,omitempty" yaml:"team,omitempty"`
+	Forecast     *models.SprintForecast `json:"forecast,omitempty" yaml:"forecast,omitempty"`
+}
+
+// Style controls how the text format draws on a terminal
+type Style struct {
+	Width int  // columns available for charts; DefaultWidth when zero
+	Color bool // color the charts and advice with ANSI escape sequences
+	ASCII bool // plain ASCII instead of emoji, box-drawing and Unicode block characters
+}
+
+// DefaultWidth is the width assumed when the terminal width is unknown
+const DefaultWidth = 80
+
+// New creates the renderer for format writing to w; the scale orders sizes, the advice
+// thresholds drive the hints shown for task combinations and the style applies to the text format
+func New(format string, w io.Writer, s scale.Scale, advice config.Advice, style Style) (Renderer, error) {
+	switch format {
+	case FormatText:
+		if style.ASCII {
+			w = ASCIIWriter(w)
+		}
+		return &textRenderer{w: w, scale: s, advice: advice, style: style}, nil
+	case FormatJSON:
+		return &structuredRenderer{w: w, marshal: marshalJSON}, nil
+	case FormatYAML:
+		return &structuredRenderer{w: w, marshal: marshalYAML}, nil
+	case FormatCSV:
+		return &csvRenderer{w: w, scale: s}, nil
+	case FormatMarkdown:
+		return &markdownRenderer{w: w, scale: s, advice: advice}, nil
+	case FormatHTML:
+		return &htmlRenderer{w: w, scale: s, advice: advice}, nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q (expected one of %v)", format, config.Formats)
+	}
+}
+
+// printer writes formatted output and keeps the first write error
+type printer struct {
+	w   io.Writer
+	err error
+}
+
+// printf writes formatted output unless an earlier write failed
+func (p *printer) printf(format string, args ...any) {
+	if p.err != nil {
+		return
+	}
+
+	_, p.err = fmt.Fprintf(p.w, format, args...)
+}
+
+// combinationAdvice returns the hints for a combination of tasks
+func combinationAdvice(s scale.Scale, thresholds config.Advice, combo models.Combination) []string {
+	var advice []string
+
+	// Task count analysis
+	totalTasks := combo.TotalTasks()
+	if totalTasks <= thresholds.LowTaskCount {
+		advice = append(advice, "💡 Low task count - excellent for focused work")
+	} else if totalTasks >= thresholds.HighTaskCount {
+		advice = append(advice, "⚠️  High task count - may cause context switching")
+	}
+
+	// Balance analysis: the largest size versus the lower half of the scale. Many small tasks
+	// means ManySmallCount of the smallest size, or one fewer for each size up but at least one,
+	// e.g. 6 XS or 5 S on the T-shirt scale.
+	large := combo.Counts[s.Largest().Name]
+	small, manySmall := 0, false
+	for i, size := range s.Small() {
+		small += combo.Counts[size.Name]
+		if combo.Counts[size.Name] >= max(thresholds.ManySmallCount-i, 1) {
+			manySmall = true
+		}
+	}
+
+	if large > 0 && small > 0 {
+		advice = append(advice, "✅ Good mix of large and small tasks")
+	} else if large >= thresholds.HeavyLargeCount {
+		advice = append(advice, "🎯 Heavy on large tasks - ensure adequate planning")
+	} else if large == 0 && manySmall {
+		advice = append(advice, "⚡ Many small tasks - good for quick wins")
+	}
+
+	return advice
+}
+
+// combinationLabel describes a combination from the largest size down, e.g. "L×3 + XS×3"
+func combinationLabel(s scale.Scale, combo models.Combination) string {
+	label := ""
+	for _, size := range s.Descending() {
+		if count := combo.Counts[size.Name]; count > 0 {
+			if label != "" {
+				label += " + "
+			}
+			label += fmt.Sprintf("%s×%d", size.Name, count)
+		}
+	}
+
+	if label == "" {
+		return "No tasks"
+	}
+
+	return label
+}
+
+// constraintsLabel describes per-size constraints, largest size first, e.g. "at most 1 L, no M, at least 3 XS"
+func constraintsLabel(s scale.Scale, c *models.Constraints) string {
+	var parts []string
+	for _, size := range s.Descending() {
+		lo := c.Min[size.Name]
+		hi, bounded := c.Max[size.Name]
+
+		switch {
+		case slices.Contains(c.Exclude, size.Name) || (bounded && hi == 0):
+			parts = append(parts, "no "+size.Name)
+		case bounded && lo == hi:
+			parts = append(parts, fmt.Sprintf("exactly %d %s", lo, size.Name))
+		case bounded && lo > 0:
+			parts = append(parts, fmt.Sprintf("%d to %d %s", lo, hi, size.Name))
+		case bounded:
+			parts = append(parts, fmt.Sprintf("at most %d %s", hi, size.Name))
+		case lo > 0:
+			parts = append(parts, fmt.Sprintf("at least %d %s", lo, size.Name))
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// configSetting represents one configuration value and its source
+type configSetting struct {
+	Key    string `json:"key" yaml:"key"`
+	Value  string `json:"value" yaml:"value"`
+	Source string `json:"source" yaml:"source"`
+}
+
+// configDocument represents the effective configuration in the structured formats
+type configDocument struct {
+	Settings []configSetting `json:"settings" yaml:"settings"`
+	Files    []string        `json:"files" yaml:"files"`
+}
+
+// newConfigDocument lists the configuration values in display order
+func newConfigDocument(cfg *config.Config) configDocument {
+	doc := configDocument{Files: append([]string{}, cfg.Files...)}
+	for _, key := range config.Keys {
+		doc.Settings = append(doc.Settings, configSetting{Key: key, Value: cfg.Value(key), Source: cfg.Source(key)})
+	}
+
+	return doc
+}
+
+// planRows returns the selected items followed by the deferred ones with their status
+func planRows(result models.PlanResult) ([]models.PlannedItem, []string) {
+	items := make([]models.PlannedItem, 0, len(result.Selected)+len(result.Deferred))
+	status := make([]string, 0, cap(items))
+	for _, item := range result.Selected {
+		items = append(items, item)
+		status = append(status, "selected")
+	}
+	for _, item := range result.Deferred {
+		items = append(items, item)
+		status = append(status, "deferred")
+	}
+
+	return items, status
+}
+
+// hasItems reports whether any breakdown lists backlog items
+func hasItems(breakdown []models.TaskBreakdown) bool {
+	for _, b := range breakdown {
+		if len(b.Items) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// digits returns the number of characters needed to print n
+func digits(n int) int {
+	return len(fmt.Sprint(n))
+}
+
+// hoursPerDay converts effort hours into working days
+const hoursPerDay = 8
@@ -0,0 +1,378 @@
+package render
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode"
+
+	"github.com/gr1m0h/sizely/internal/calculator"
+	"github.com/gr1m0h/sizely/internal/calendar"
+	"github.com/gr1m0h/sizely/internal/config"
+	"github.com/gr1m0h/sizely/internal/models"
+	"github.com/gr1m0h/sizely/internal/scale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// extensions maps each format to the file extension of its golden files
+var extensions = map[string]string{
+	FormatText:     "txt",
+	FormatJSON:     "json",
+	FormatYAML:     "yaml",
+	FormatCSV:      "csv",
+	FormatMarkdown: "md",
+	FormatHTML:     "html",
+}
+
+func capacityFixture(t *testing.T) CapacityReport {
+	t.Helper()
+
+	calc := calculator.NewCalculator()
+	capacity, err := calc.CalculateBacklogCapacity([]models.BacklogItem{
+		{ID: "API-12", Title: "Add rate limiting | throttling", Size: "M"},
+		{ID: "API-15", Title: "Payment provider webhook handler", Size: "L"},
+		{ID: "WEB-3", Title: "Fix typo on pricing page", Size: "XS"},
+	})
+	require.NoError(t, err)
+
+	days := 6.0
+	team, err := calc.CalculateTeamCapacity(models.Team{
+		Name:         "Platform",
+		PointsPerDay: 2,
+		SprintDays:   10,
+		Members:      []models.Member{{Name: "Alice"}, {Name: "Bob", Days: &days}},
+	}, capacity.TotalPoints)
+	require.NoError(t, err)
+
+	date := func(s string) time.Time {
+		d, err := calendar.ParseDate(s)
+		require.NoError(t, err)
+		return d
+	}
+
+	return CapacityReport{
+		Capacity: capacity,
+		Availability: &calendar.Availability{
+			Start:       date("2026-10-05"),
+			End:         date("2026-10-16"),
+			WeekDays:    10,
+			Holidays:    []calendar.Holiday{{Date: date("2026-10-12"), Name: "Sports Day"}},
+			WorkingDays: 9,
+			Members:     []calendar.MemberAvailability{{Name: "Alice", PTODays: 1, Days: 8}, {Name: "Bob", Days: 9}},
+		},
+		Team: &team,
+		Forecast: &models.SprintForecast{
+			PlannedPoints: capacity.TotalPoints,
+			Trials:        1000,
+			Sprints:       4,
+			Probability:   0.62,
+			Percentiles: []models.ForecastPercentile{
+				{Confidence: 50, Points: 18},
+				{Confidence: 85, Points: 13},
+				{Confidence: 95, Points: 10},
+			},
+		},
+	}
+}
+
+func planFixture(t *testing.T) models.PlanResult {
+	t.Helper()
+
+	result, err := calculator.NewCalculator().PlanBacklog([]models.BacklogItem{
+		{ID: "API-12", Title: "Add rate limiting", Size: "M"},
+		{ID: "API-15", Title: "Payment provider webhook handler", Size: "L", DependsOn: []string{"OPS-2"}},
+		{ID: "WEB-7", Title: "Dark mode toggle", Size: "S"},
+		{ID: "OPS-2", Title: "Rotate staging credentials", Size: "XS"},
+	}, 16, 3)
+	require.NoError(t, err)
+
+	return result
+}
+
+func velocityFixture(t *testing.T) models.VelocityReport {
+	t.Helper()
+
+	report, err := calculator.NewCalculator().CalculateVelocity([]models.SprintRecord{
+		{Sprint: "41", Start: "2026-09-21", End: "2026-10-02", Planned: models.TaskCount{"S": 2, "M": 2}, Completed: models.TaskCount{"S": 2, "M": 1}, PlannedPoints: 16, CompletedPoints: 11},
+		{Sprint: "42", Planned: models.TaskCount{"M": 2, "L": 1}, Completed: models.TaskCount{"M": 2, "L": 1}, PlannedPoints: 20, CompletedPoints: 20},
+	}, 3)
+	require.NoError(t, err)
+
+	return report
+}
+
+func releaseFixture() models.ReleaseForecast {
+	return models.ReleaseForecast{
+		TotalPoints:  70,
+		TotalTasks:   9,
+		Start:        "2026-11-02",
+		SprintLength: 14,
+		Trials:       1000,
+		Sprints:      3,
+		Percentiles: []models.ReleasePercentile{
+			{Confidence: 50, Sprints: 6, Finish: "2027-01-24"},
+			{Confidence: 85, Sprints: 8, Finish: "2027-02-21"},
+			{Confidence: 95, Sprints: 9, Finish: "2027-03-07"},
+		},
+	}
+}
+
+func configFixture(t *testing.T) *config.Config {
+	t.Helper()
+
+	cfg := config.Default()
+	require.NoError(t, cfg.Set(config.KeyMaxTasks, "12", "project config /work/app/.sizely.yaml"))
+	cfg.Files = []string{"/work/app/.sizely.yaml"}
+
+	return cfg
+}
+
+func TestRenderers(t *testing.T) {
+	documents := map[string]func(Renderer) error{
+		"capacity": func(r Renderer) error { return r.Capacity(capacityFixture(t)) },
+		"combinations": func(r Renderer) error {
+			return r.Combinations(calculator.NewCalculator().FindCombinations(13, 4))
+		},
+		"plan":     func(r Renderer) error { return r.Plan(planFixture(t)) },
+		"velocity": func(r Renderer) error { return r.Velocity(velocityFixture(t)) },
+		"release":  func(r Renderer) error { return r.Release(releaseFixture()) },
+		"config":   func(r Renderer) error { return r.Config(configFixture(t)) },
+	}
+
+	for _, format := range config.Formats {
+		for name, render := range documents {
+			t.Run(format+"/"+name, func(t *testing.T) {
+				var buf bytes.Buffer
+				r, err := New(format, &buf, scale.Default(), config.Default().Advice, Style{})
+				require.NoError(t, err)
+				require.NoError(t, render(r))
+
+				golden := filepath.Join("testdata", name+"."+extensions[format])
+				if *update {
+					require.NoError(t, os.WriteFile(golden, buf.Bytes(), 0o644))
+				}
+
+				want, err := os.ReadFile(golden)
+				require.NoError(t, err)
+				assert.Equal(t, string(want), buf.String())
+			})
+		}
+	}
+}
+
+func TestNewUnknownFormat(t *testing.T) {
+	_, err := New("xml", &bytes.Buffer{}, scale.Default(), config.Default().Advice, Style{})
+	assert.ErrorContains(t, err, `unknown output format "xml"`)
+}
+
+// failingWriter rejects every write
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestWriteErrors(t *testing.T) {
+	for _, format := range config.Formats {
+		t.Run(format, func(t *testing.T) {
+			r, err := New(format, failingWriter{}, scale.Default(), config.Default().Advice, Style{})
+			require.NoError(t, err)
+			assert.ErrorContains(t, r.Release(releaseFixture()), "disk full")
+		})
+	}
+}
+
+func TestHTMLEscapes(t *testing.T) {
+	capacity, err := calculator.NewCalculator().CalculateBacklogCapacity([]models.BacklogItem{
+		{ID: "WEB-1", Title: `Fix <script>alert("x")</script> & friends`, Size: "S"},
+	})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	r, err := New(FormatHTML, &buf, scale.Default(), config.Default().Advice, Style{})
+	require.NoError(t, err)
+	require.NoError(t, r.Capacity(CapacityReport{Capacity: capacity}))
+
+	assert.NotContains(t, buf.String(), "<script>")
+	assert.Contains(t, buf.String(), "Fix &lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt; &amp; friends")
+	assert.Contains(t, buf.String(), `<circle cx="110" cy="110" r="100"`, "a single size fills the pie")
+}
+
+func TestBar(t *testing.T) {
+	tests := []struct {
+		name     string
+		share    float64
+		width    int
+		ascii    bool
+		expected string
+	}{
+		{name: "empty", share: 0, width: 4, expected: "    "},
+		{name: "full", share: 1, width: 4, expected: "████"},
+		{name: "partial block", share: 0.5625, width: 4, expected: "██▎ "},
+		{name: "clamped", share: 1.5, width: 2, expected: "██"},
+		{name: "ascii", share: 0.5, width: 4, ascii: true, expected: "##  "},
+		{name: "ascii rounds", share: 0.3, width: 10, ascii: true, expected: "###       "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, bar(tt.share, tt.width, tt.ascii))
+		})
+	}
+}
+
+func TestSizeMixStyle(t *testing.T) {
+	render := func(style Style) string {
+		var buf bytes.Buffer
+		r, err := New(FormatText, &buf, scale.Default(), config.Default().Advice, style)
+		require.NoError(t, err)
+		require.NoError(t, r.Capacity(CapacityReport{Capacity: calculator.NewCalculator().CalculateSprintCapacity(models.TaskCount{"XS": 1, "L": 1})}))
+		return buf.String()
+	}
+
+	assert.NotContains(t, render(Style{}), "\x1b[")
+	assert.Contains(t, render(Style{Color: true}), "\x1b[34m█")
+
+	plain := render(Style{ASCII: true})
+	assert.Contains(t, plain, "L   ######")
+	assert.NotContains(t, plain, "█")
+
+	narrow, wide := render(Style{Width: 30}), render(Style{Width: 200})
+	assert.Contains(t, narrow, "XS  ▉"+strings.Repeat(" ", minBarWidth-1)+"   9%")
+	assert.Contains(t, wide, strings.Repeat("█", 36)+"▍"+strings.Repeat(" ", 3)+"  91%", "bars stop growing at the maximum width")
+}
+
+func TestTextASCII(t *testing.T) {
+	documents := map[string]func(Renderer) error{
+		"capacity": func(r Renderer) error { return r.Capacity(capacityFixture(t)) },
+		"combinations": func(r Renderer) error {
+			return r.Combinations(calculator.NewCalculator().FindCombinations(13, 4))
+		},
+		"plan":     func(r Renderer) error { return r.Plan(planFixture(t)) },
+		"velocity": func(r Renderer) error { return r.Velocity(velocityFixture(t)) },
+		"release":  func(r Renderer) error { return r.Release(releaseFixture()) },
+		"config":   func(r Renderer) error { return r.Config(configFixture(t)) },
+	}
+
+	for name, render := range documents {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			r, err := New(FormatText, &buf, scale.Default(), config.Default().Advice, Style{ASCII: true})
+			require.NoError(t, err)
+			require.NoError(t, render(r))
+
+			for i, c := range buf.String() {
+				if c > unicode.MaxASCII {
+					t.Fatalf("non-ASCII %q at byte %d of:\n%s", c, i, buf.String())
+				}
+			}
+		})
+	}
+}
+
+func TestTextSeverityColors(t *testing.T) {
+	var buf bytes.Buffer
+	r, err := New(FormatText, &buf, scale.Default(), config.Default().Advice, Style{Color: true})
+	require.NoError(t, err)
+	require.NoError(t, r.Capacity(capacityFixture(t)))
+	require.NoError(t, r.Combinations(calculator.NewCalculator().FindCombinations(13, 2)))
+
+	assert.Contains(t, buf.String(), "\x1b[33m⚠️  Uncertain")
+	assert.Contains(t, buf.String(), "\x1b[32m✅ Good mix of large and small tasks\x1b[0m")
+	assert.Contains(t, buf.String(), "\n📊 Sprint Capacity"[1:], "headings are not colored")
+}
+
+func TestCombinationAdvice(t *testing.T) {
+	const quickWins = "⚡ Many small tasks - good for quick wins"
+
+	tests := []struct {
+		name     string
+		counts   models.TaskCount
+		expected bool
+	}{
+		{name: "six XS", counts: models.TaskCount{"XS": 6}, expected: true},
+		{name: "five XS", counts: models.TaskCount{"XS": 5}},
+		{name: "five S", counts: models.TaskCount{"S": 5}, expected: true},
+		{name: "four S", counts: models.TaskCount{"S": 4, "M": 1}},
+		{name: "three XS and three S", counts: models.TaskCount{"XS": 3, "S": 3}},
+		{name: "with an L", counts: models.TaskCount{"XS": 6, "L": 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			advice := combinationAdvice(scale.Default(), config.Default().Advice, models.Combination{Counts: tt.counts})
+			if tt.expected {
+				assert.Contains(t, advice, quickWins)
+			} else {
+				assert.NotContains(t, advice, quickWins)
+			}
+		})
+	}
+}
+
+func TestCombinationAdviceLongScale(t *testing.T) {
+	// With 16 sizes the lower half has 8, more than many_small_count
+	sizes := make([]scale.Size, 16)
+	for i := range sizes {
+		sizes[i] = scale.Size{Name: fmt.Sprintf("S%d", i+1), Points: i + 1}
+	}
+	long, err := scale.New("long", sizes)
+	require.NoError(t, err)
+
+	advice := combinationAdvice(long, config.Default().Advice, models.Combination{Counts: models.TaskCount{"S10": 1}})
+	assert.NotContains(t, advice, "⚡ Many small tasks - good for quick wins")
+
+	advice = combinationAdvice(long, config.Default().Advice, models.Combination{Counts: models.TaskCount{"S8": 1}})
+	assert.Contains(t, advice, "⚡ Many small tasks - good for quick wins", "the threshold never drops below one task")
+}
+
+func TestConstraintsLabel(t *testing.T) {
+	tests := []struct {
+		name        string
+		constraints models.Constraints
+		expected    string
+	}{
+		{name: "none", expected: ""},
+		{name: "excluded", constraints: models.Constraints{Exclude: []string{"M"}}, expected: "no M"},
+		{name: "zero maximum", constraints: models.Constraints{Max: models.TaskCount{"S": 0}}, expected: "no S"},
+		{name: "exact", constraints: models.Constraints{Min: models.TaskCount{"L": 2}, Max: models.TaskCount{"L": 2}}, expected: "exactly 2 L"},
+		{name: "range", constraints: models.Constraints{Min: models.TaskCount{"S": 1}, Max: models.TaskCount{"S": 3}}, expected: "1 to 3 S"},
+		{
+			name:        "largest first",
+			constraints: models.Constraints{Min: models.TaskCount{"XS": 3}, Max: models.TaskCount{"L": 1}, Exclude: []string{"M"}},
+			expected:    "at most 1 L, no M, at least 3 XS",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, constraintsLabel(scale.Default(), &tt.constraints))
+		})
+	}
+}
+
+func TestConstrainedCombinations(t *testing.T) {
+	result, err := calculator.NewCalculator().FindCombinationsContext(context.Background(), 13, 4,
+		models.Constraints{Max: models.TaskCount{"L": 0}})
+	require.NoError(t, err)
+
+	for _, format := range []string{FormatText, FormatMarkdown, FormatHTML} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			r, err := New(format, &buf, scale.Default(), config.Default().Advice, Style{})
+			require.NoError(t, err)
+			require.NoError(t, r.Combinations(result))
+			assert.Contains(t, buf.String(), "Constraints: no L")
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/gr1m0h/sizely/internal/config"
+	"github.com/gr1m0h/sizely/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// structuredRenderer writes each result as a single JSON or YAML document and nothing else,
+// so the output can be piped to tools like jq or yq
+type structuredRenderer struct {
+	w       io.Writer
+	marshal func(v any) ([]byte, error)
+}
+
+// marshalJSON encodes v as indented JSON ending with a newline
+func marshalJSON(v any) ([]byte, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encoding JSON: %w", err)
+	}
+
+	return append(data, '\n'), nil
+}
+
+// marshalYAML encodes v as YAML indented by two spaces
+func marshalYAML(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return nil, fmt.Errorf("encoding YAML: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("encoding YAML: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// write encodes v and writes it out
+func (r *structuredRenderer) write(v any) error {
+	data, err := r.marshal(v)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.w.Write(data)
+
+	return err
+}
+
+// Capacity writes the points report
+func (r *structuredRenderer) Capacity(report CapacityReport) error {
+	return r.write(report)
+}
+
+// Combinations writes the reverse calculation result
+func (r *structuredRenderer) Combinations(result models.CombinationResult) error {
+	return r.write(result)
+}
+
+// Plan writes the sprint plan
+func (r *structuredRenderer) Plan(result models.PlanResult) error {
+	return r.write(result)
+}
+
+// Velocity writes the velocity report
+func (r *structuredRenderer) Velocity(report models.VelocityReport) error {
+	return r.write(report)
+}
+
+// Release writes the release forecast
+func (r *structuredRenderer) Release(forecast models.ReleaseForecast) error {
+	return r.write(forecast)
+}
+
+// Config writes the configuration settings and the config files they came from
+func (r *structuredRenderer) Config(cfg *config.Config) error {
+	return r.write(newConfigDocument(cfg))
+}
@@ -0,0 +1,167 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/gr1m0h/sizely/internal/calendar"
+	"github.com/gr1m0h/sizely/internal/config"
+	"github.com/gr1m0h/sizely/internal/models"
+	"github.com/gr1m0h/sizely/internal/scale"
+)
+
+// templateRenderer executes a user-defined text/template against each result
+type templateRenderer struct {
+	w        io.Writer
+	template *template.Template
+}
+
+// TemplateCapacity is the data of a template rendering the points result: the sprint capacity
+// with the team and forecast sections, which are nil when not requested
+type TemplateCapacity struct {
+	models.SprintCapacity
+	Availability *calendar.Availability
+	Team         *models.TeamCapacity
+	Forecast     *models.SprintForecast
+}
+
+// NewTemplate creates a renderer executing the template text; besides the text/template builtins,
+// templates can use the helpers listed in TemplateFuncs
+func NewTemplate(text string, w io.Writer, s scale.Scale, advice config.Advice) (Renderer, error) {
+	tmpl, err := template.New("sizely").Funcs(TemplateFuncs(s, advice)).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parsing template: %w", err)
+	}
+
+	return &templateRenderer{w: w, template: tmpl}, nil
+}
+
+// TemplateFuncs returns the helper functions available to templates:
+//
+//	pad N S        S padded with spaces on the right to N characters
+//	padLeft N S    S padded with spaces on the left to N characters
+//	sizes          the sizes of the scale, smallest first (each with .Name and .Points)
+//	descending     the sizes of the scale, largest first
+//	percent A B    A as a percentage of B, e.g. "42%"
+//	ratio F        a 0-1 ratio as a percentage, e.g. "62%"
+//	add A B, mul A B  integer arithmetic, e.g. mul $count .Points
+//	label C        a combination as "L×1 + S×1"
+//	advice C       the advice lines for a combination
+//	join SEP LIST  the strings of LIST joined by SEP
+//	repeat N S     S repeated N times
+//	upper S, lower S
+func TemplateFuncs(s scale.Scale, advice config.Advice) template.FuncMap {
+	return template.FuncMap{
+		"pad": func(width int, v any) string {
+			return fmt.Sprintf("%-*v", width, v)
+		},
+		"padLeft": func(width int, v any) string {
+			return fmt.Sprintf("%*v", width, v)
+		},
+		"sizes": func() []scale.Size {
+			return s.Sizes
+		},
+		"descending": func() []scale.Size {
+			return s.Descending()
+		},
+		"percent": func(part, total any) (string, error) {
+			p, err := toFloat(part)
+			if err != nil {
+				return "", err
+			}
+			t, err := toFloat(total)
+			if err != nil {
+				return "", err
+			}
+			if t == 0 {
+				return "0%", nil
+			}
+			return fmt.Sprintf("%.0f%%", p/t*100), nil
+		},
+		"ratio": func(r float64) string {
+			return fmt.Sprintf("%.0f%%", r*100)
+		},
+		"add": func(a, b int) int {
+			return a + b
+		},
+		"mul": func(a, b int) int {
+			return a * b
+		},
+		"label": func(combo models.Combination) string {
+			return combinationLabel(s, combo)
+		},
+		"advice": func(combo models.Combination) []string {
+			return combinationAdvice(s, advice, combo)
+		},
+		"join": func(sep string, list []string) string {
+			return strings.Join(list, sep)
+		},
+		"repeat": func(n int, s string) string {
+			return strings.Repeat(s, max(n, 0))
+		},
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+	}
+}
+
+// toFloat converts the numbers found in results to float64
+func toFloat(v any) (float64, error) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), nil
+	case float64:
+		return n, nil
+	default:
+		return 0, fmt.Errorf("percent: expected a number, got %T", v)
+	}
+}
+
+// execute runs the template against data and writes the output only when it succeeds
+func (r *templateRenderer) execute(data any) error {
+	var buf bytes.Buffer
+	if err := r.template.Execute(&buf, data); err != nil {
+		return fmt.Errorf("executing template: %w", err)
+	}
+
+	_, err := r.w.Write(buf.Bytes())
+
+	return err
+}
+
+// Capacity executes the template against a TemplateCapacity
+func (r *templateRenderer) Capacity(report CapacityReport) error {
+	return r.execute(TemplateCapacity{
+		SprintCapacity: report.Capacity,
+		Availability:   report.Availability,
+		Team:           report.Team,
+		Forecast:       report.Forecast,
+	})
+}
+
+// Combinations executes the template against the reverse calculation result
+func (r *templateRenderer) Combinations(result models.CombinationResult) error {
+	return r.execute(result)
+}
+
+// Plan executes the template against the sprint plan
+func (r *templateRenderer) Plan(result models.PlanResult) error {
+	return r.execute(result)
+}
+
+// Velocity executes the template against the velocity report
+func (r *templateRenderer) Velocity(report models.VelocityReport) error {
+	return r.execute(report)
+}
+
+// Release executes the template against the release forecast
+func (r *templateRenderer) Release(forecast models.ReleaseForecast) error {
+	return r.execute(forecast)
+}
+
+// Config executes the template against the effective configuration
+func (r *templateRenderer) Config(cfg *config.Config) error {
+	return r.execute(cfg)
+}
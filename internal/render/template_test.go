@@ -0,0 +1,106 @@
+package render
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gr1m0h/sizely/internal/calculator"
+	"github.com/gr1m0h/sizely/internal/config"
+	"github.com/gr1m0h/sizely/internal/scale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateRenderer(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		render   func(Renderer) error
+		expected string
+	}{
+		{
+			name:     "capacity fields",
+			template: "{{.TotalTasks}} tasks, {{.TotalPoints}} points, {{.Team.Team}} at {{ratio .Forecast.Probability}}",
+			render:   func(r Renderer) error { return r.Capacity(capacityFixture(t)) },
+			expected: "3 tasks, 16 points, Platform at 62%",
+		},
+		{
+			name:     "size ordering and padding",
+			template: "{{range sizes}}[{{pad 3 .Name}}{{padLeft 3 .Points}}]{{end}}",
+			render:   func(r Renderer) error { return r.Capacity(capacityFixture(t)) },
+			expected: "[XS   1][S    3][M    5][L   10]",
+		},
+		{
+			name:     "descending sizes with counts",
+			template: "{{range descending}}{{.Name}}={{index $.Tasks .Name}} {{end}}",
+			render:   func(r Renderer) error { return r.Capacity(capacityFixture(t)) },
+			expected: "L=1 M=1 S=0 XS=1 ",
+		},
+		{
+			name:     "percentages",
+			template: `{{percent 5 16}} {{percent (mul 2 5) 20}} {{percent 1 0}} {{percent 1.5 3.0}}`,
+			render:   func(r Renderer) error { return r.Capacity(capacityFixture(t)) },
+			expected: "31% 50% 0% 50%",
+		},
+		{
+			name:     "combinations",
+			template: `{{range .Combinations}}{{label .}}: {{join "; " (advice .)}}{{"\n"}}{{end}}`,
+			render: func(r Renderer) error {
+				return r.Combinations(calculator.NewCalculator().FindCombinations(13, 2))
+			},
+			expected: "L×1 + S×1: 💡 Low task count - excellent for focused work; ✅ Good mix of large and small tasks\n",
+		},
+		{
+			name:     "other documents",
+			template: "{{.TotalPoints}} points: {{range .Percentiles}}{{.Confidence}}%={{.Finish}} {{end}}",
+			render:   func(r Renderer) error { return r.Release(releaseFixture()) },
+			expected: "70 points: 50%=2027-01-24 85%=2027-02-21 95%=2027-03-07 ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			r, err := NewTemplate(tt.template, &buf, scale.Default(), config.Default().Advice)
+			require.NoError(t, err)
+			require.NoError(t, tt.render(r))
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
+
+func TestTemplateExample(t *testing.T) {
+	text, err := os.ReadFile(filepath.Join("..", "..", "examples", "templates", "announcement.tmpl"))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	r, err := NewTemplate(string(text), &buf, scale.Default(), config.Default().Advice)
+	require.NoError(t, err)
+	require.NoError(t, r.Capacity(capacityFixture(t)))
+
+	golden := filepath.Join("testdata", "announcement.txt")
+	if *update {
+		require.NoError(t, os.WriteFile(golden, buf.Bytes(), 0o644))
+	}
+
+	want, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), buf.String())
+}
+
+func TestTemplateErrors(t *testing.T) {
+	_, err := NewTemplate("{{.TotalPoints", &bytes.Buffer{}, scale.Default(), config.Default().Advice)
+	assert.ErrorContains(t, err, "parsing template")
+
+	var buf bytes.Buffer
+	r, err := NewTemplate("partial {{.Missing}}", &buf, scale.Default(), config.Default().Advice)
+	require.NoError(t, err)
+	assert.ErrorContains(t, r.Capacity(capacityFixture(t)), "executing template")
+	assert.Empty(t, buf.String(), "nothing is written when the template fails")
+
+	r, err = NewTemplate(`{{percent "a" 2}}`, &buf, scale.Default(), config.Default().Advice)
+	require.NoError(t, err)
+	assert.ErrorContains(t, r.Capacity(capacityFixture(t)), "expected a number")
+}
@@ -0,0 +1,483 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/gr1m0h/sizely/internal/calendar"
+	"github.com/gr1m0h/sizely/internal/config"
+	"github.com/gr1m0h/sizely/internal/models"
+	"github.com/gr1m0h/sizely/internal/scale"
+)
+
+// textRenderer writes human-readable tables
+type textRenderer struct {
+	w      io.Writer
+	scale  scale.Scale
+	advice config.Advice
+	style  Style
+}
+
+// Capacity prints the sprint capacity followed by the effort, backlog items, team and forecast sections that apply
+func (r *textRenderer) Capacity(report CapacityReport) error {
+	p := &printer{w: r.w}
+	capacity := report.Capacity
+
+	labels := make([]string, len(capacity.Breakdown))
+	labelWidth, countWidth, totalWidth := len("Total:"), digits(capacity.TotalTasks), digits(capacity.TotalPoints)
+	for i, b := range capacity.Breakdown {
+		labels[i] = fmt.Sprintf("%s (%dpt):", b.Size, b.Points)
+		labelWidth = max(labelWidth, len(labels[i]))
+		countWidth = max(countWidth, digits(b.Count))
+		totalWidth = max(totalWidth, digits(b.Total))
+	}
+
+	p.printf("📊 Sprint Capacity Calculation\n")
+	p.printf("═══════════════════════════════\n")
+	for i, b := range capacity.Breakdown {
+		p.printf("%-*s   %*d tasks = %*d points\n", labelWidth, labels[i], countWidth, b.Count, totalWidth, b.Total)
+	}
+	p.printf("───────────────────────────────\n")
+	p.printf("%-*s   %*d tasks = %*d points\n", labelWidth, "Total:", countWidth, capacity.TotalTasks, totalWidth, capacity.TotalPoints)
+	p.printf("\n")
+
+	r.printSizeMix(p, capacity)
+	r.printEffort(p, capacity.Effort)
+	printBacklogItems(p, capacity.Breakdown)
+	if report.Availability != nil {
+		printAvailability(p, *report.Availability)
+	}
+	if report.Team != nil {
+		r.printTeamCapacity(p, *report.Team)
+	}
+	if report.Forecast != nil {
+		r.printForecast(p, *report.Forecast)
+	}
+
+	return p.err
+}
+
+// printSizeMix draws each size's share of the points and of the tasks as bars sized to the terminal
+func (r *textRenderer) printSizeMix(p *printer, capacity models.SprintCapacity) {
+	if capacity.TotalTasks == 0 || capacity.TotalPoints == 0 {
+		return
+	}
+
+	sizeWidth := 0
+	for _, b := range capacity.Breakdown {
+		sizeWidth = max(sizeWidth, len(b.Size))
+	}
+
+	// Each row holds the size, two bars and their percentages: "XS  <bar> 100%   <bar> 100%"
+	width := r.style.Width
+	if width <= 0 {
+		width = DefaultWidth
+	}
+	barWidth := min(max((width-sizeWidth-2-5-3-5)/2, minBarWidth), maxBarWidth)
+
+	p.printf("📈 Size Mix\n")
+	p.printf("═══════════════════════════════\n")
+	p.printf("%-*s  %-*s   %s\n", sizeWidth, "", barWidth+5, "Points", "Tasks")
+	for i, b := range capacity.Breakdown {
+		pointShare := float64(b.Total) / float64(capacity.TotalPoints)
+		taskShare := float64(b.Count) / float64(capacity.TotalTasks)
+		p.printf("%-*s  %s %3.0f%%   %s %3.0f%%\n", sizeWidth, b.Size,
+			r.colored(i, bar(pointShare, barWidth, r.style.ASCII)), pointShare*100,
+			r.colored(i, bar(taskShare, barWidth, r.style.ASCII)), taskShare*100)
+	}
+	p.printf("\n")
+}
+
+// Bar widths of the size mix chart, in characters
+const (
+	minBarWidth = 10
+	maxBarWidth = 40
+)
+
+// partialBlocks draws the fractional end of a Unicode bar in eighths of a character
+var partialBlocks = []string{"", "▏", "▎", "▍", "▌", "▋", "▊", "▉"}
+
+// bar draws share (0-1) of width characters, padded with spaces to width
+func bar(share float64, width int, ascii bool) string {
+	share = min(max(share, 0), 1)
+	if ascii {
+		full := int(math.Round(share * float64(width)))
+		return strings.Repeat("#", full) + strings.Repeat(" ", width-full)
+	}
+
+	eighths := int(math.Round(share * float64(width) * 8))
+	full, rest := eighths/8, eighths%8
+	drawn := strings.Repeat("█", full) + partialBlocks[rest]
+	if rest > 0 {
+		full++
+	}
+
+	return drawn + strings.Repeat(" ", width-full)
+}
+
+// sizeColors are the ANSI foreground colors of the sizes in scale order, repeating for longer scales
+var sizeColors = []int{34, 33, 31, 36, 32, 35}
+
+// colored wraps s in the ANSI color of the size at index i when the style uses colors
+func (r *textRenderer) colored(i int, s string) string {
+	if !r.style.Color {
+		return s
+	}
+
+	return ansi(sizeColors[i%len(sizeColors)], s)
+}
+
+// severities colors advice and status lines by their leading symbol: red alerts, yellow warnings and green good news
+var severities = []struct {
+	symbol string
+	color  int
+}{
+	{"🚨", 31},
+	{"⚠️", 33},
+	{"🎯", 33},
+	{"💤", 33},
+	{"✅", 32},
+	{"💡", 32},
+	{"⚡", 32},
+}
+
+// tone colors an advice or status line by its severity when the style uses colors
+func (r *textRenderer) tone(line string) string {
+	if !r.style.Color {
+		return line
+	}
+
+	for _, severity := range severities {
+		if strings.HasPrefix(line, severity.symbol) {
+			return ansi(severity.color, line)
+		}
+	}
+
+	return line
+}
+
+// ansi wraps s in an ANSI foreground color
+func ansi(color int, s string) string {
+	return fmt.Sprintf("\x1b[%dm%s\x1b[0m", color, s)
+}
+
+// asciiReplacer spells the emoji, box-drawing and other symbols of the text output in plain ASCII
+var asciiReplacer = strings.NewReplacer(
+	// Section headings lose their emoji
+	"📊 ", "", "📈 ", "", "⏱️  ", "", "📋 ", "", "📅 ", "", "👥 ", "", "🎲 ", "",
+	"🔍 ", "", "🗂️  ", "", "⏭️  ", "", "🚀 ", "", "⚙️  ", "", "📝 ", "",
+	// Advice and status lines keep a marker of their severity
+	"🚨 ", "[!!] ", "⚠️  ", "[!] ", "🎯 ", "[!] ", "💤 ", "[-] ", "✅ ", "[ok] ", "💡 ", "[i] ", "⚡ ", "[+] ",
+	"═", "=", "─", "-", "•", "*", "×", "x", "→", "->", "↳", "->", "±", "+/-",
+)
+
+// asciiWriter replaces the symbols of the text output with plain ASCII on their way to w
+type asciiWriter struct {
+	w io.Writer
+}
+
+// ASCIIWriter returns a writer replacing the emoji and box-drawing characters sizely prints with plain ASCII
+func ASCIIWriter(w io.Writer) io.Writer {
+	return asciiWriter{w: w}
+}
+
+// Write writes b to the underlying writer with its symbols replaced
+func (a asciiWriter) Write(b []byte) (int, error) {
+	if _, err := asciiReplacer.WriteString(a.w, string(b)); err != nil {
+		return 0, err
+	}
+
+	return len(b), nil
+}
+
+// printEffort prints the PERT range of hours needed for the tasks, when the scale defines hours
+func (r *textRenderer) printEffort(p *printer, effort *models.Effort) {
+	if effort == nil {
+		return
+	}
+
+	p.printf("⏱️  Estimated Effort (PERT)\n")
+	p.printf("═══════════════════════════════\n")
+	p.printf("Min:       %6.1f hours (%.1f days)\n", effort.MinHours, effort.MinHours/hoursPerDay)
+	p.printf("Expected:  %6.1f hours (%.1f days) ± %.1f hours\n", effort.ExpectedHours, effort.ExpectedHours/hoursPerDay, effort.StdDevHours)
+	p.printf("Max:       %6.1f hours (%.1f days)\n", effort.MaxHours, effort.MaxHours/hoursPerDay)
+	if len(effort.Unestimated) > 0 {
+		p.printf("%s\n", r.tone(fmt.Sprintf("⚠️  No hour range for %s; those tasks are not included", strings.Join(effort.Unestimated, ", "))))
+	}
+	p.printf("\n")
+}
+
+// printBacklogItems lists the backlog items of each size, when the capacity came from a backlog
+func printBacklogItems(p *printer, breakdown []models.TaskBreakdown) {
+	if !hasItems(breakdown) {
+		return
+	}
+
+	idWidth := 0
+	for _, b := range breakdown {
+		for _, item := range b.Items {
+			idWidth = max(idWidth, len(item.ID))
+		}
+	}
+
+	p.printf("📋 Tasks by Size\n")
+	p.printf("═══════════════════════════════\n")
+	for _, b := range breakdown {
+		if len(b.Items) == 0 {
+			continue
+		}
+
+		p.printf("%s (%d tasks, %d points):\n", b.Size, b.Count, b.Total)
+		for _, item := range b.Items {
+			p.printf("  • %s\n", strings.TrimRight(fmt.Sprintf("%-*s  %s", idWidth, item.ID, item.Title), " "))
+		}
+	}
+	p.printf("\n")
+}
+
+// printAvailability prints the sprint's working days and each member's days off
+func printAvailability(p *printer, availability calendar.Availability) {
+	nameWidth := 0
+	for _, member := range availability.Members {
+		nameWidth = max(nameWidth, len(member.Name))
+	}
+
+	p.printf("📅 Sprint Calendar: %s to %s\n", availability.Start.Format(calendar.DateLayout), availability.End.Format(calendar.DateLayout))
+	p.printf("═══════════════════════════════\n")
+	p.printf("Weekdays:      %d\n", availability.WeekDays)
+	for _, holiday := range availability.Holidays {
+		p.printf("Holiday:       %s %s\n", holiday.Date.Format(calendar.DateLayout), holiday.Name)
+	}
+	p.printf("Working days:  %d\n", availability.WorkingDays)
+	p.printf("───────────────────────────────\n")
+	for _, member := range availability.Members {
+		p.printf("%-*s  %2d days available (%d PTO)\n", nameWidth, member.Name, member.Days, member.PTODays)
+	}
+	p.printf("\n")
+}
+
+// printTeamCapacity prints each member's capacity and how the plan compares with the team's total
+func (r *textRenderer) printTeamCapacity(p *printer, capacity models.TeamCapacity) {
+	nameWidth := 0
+	for _, member := range capacity.Members {
+		nameWidth = max(nameWidth, len(member.Name))
+	}
+
+	if capacity.Team == "" {
+		p.printf("👥 Team Capacity\n")
+	} else {
+		p.printf("👥 Team Capacity: %s\n", capacity.Team)
+	}
+	p.printf("═══════════════════════════════\n")
+	for _, member := range capacity.Members {
+		p.printf("%-*s  %4.1f days × %.2f focus = %5.1f points\n", nameWidth, member.Name, member.Days, member.FocusFactor, member.Points)
+	}
+	p.printf("───────────────────────────────\n")
+	p.printf("Capacity:  %.1f points (%.1f points per focused day)\n", capacity.CapacityPoints, capacity.PointsPerDay)
+	p.printf("Planned:   %d points (%.0f%% of capacity)\n", capacity.PlannedPoints, capacity.Utilization*100)
+	p.printf("%s\n", r.tone(commitmentSummary(capacity)))
+	p.printf("\n")
+}
+
+// printForecast prints the simulated chance of completing the planned points and the points reached per confidence level
+func (r *textRenderer) printForecast(p *printer, forecast models.SprintForecast) {
+	p.printf("🎲 Sprint Forecast (%d simulations from %d past sprint(s))\n", forecast.Trials, forecast.Sprints)
+	p.printf("═══════════════════════════════\n")
+	p.printf("Chance to complete %d points: %.0f%%\n", forecast.PlannedPoints, forecast.Probability*100)
+	p.printf("───────────────────────────────\n")
+	for _, pct := range forecast.Percentiles {
+		p.printf("P%d: at least %d points\n", pct.Confidence, pct.Points)
+	}
+	p.printf("%s\n", r.tone(forecastSummary(forecast)))
+	p.printf("\n")
+}
+
+// Combinations prints reverse calculation results with advice for each combination
+func (r *textRenderer) Combinations(result models.CombinationResult) error {
+	p := &printer{w: r.w}
+
+	p.printf("🔍 Finding combinations for %d points (max %d tasks)\n", result.TargetPoints, result.MaxTasks)
+	p.printf("═══════════════════════════════════════════════════\n")
+	if result.Constraints != nil {
+		p.printf("Constraints: %s\n", constraintsLabel(r.scale, result.Constraints))
+	}
+
+	if result.TotalFound == 0 {
+		p.printf("No combinations found for %d points with max %d tasks\n", result.TargetPoints, result.MaxTasks)
+		return p.err
+	}
+
+	p.printf("Found %d combination(s):\n\n", result.TotalFound)
+
+	for i, combo := range result.Combinations {
+		p.printf("%2d. %s = %d points (%d tasks)\n", i+1, combinationLabel(r.scale, combo), combo.Points, combo.TotalTasks())
+		for _, tip := range combinationAdvice(r.scale, r.advice, combo) {
+			p.printf("    %s\n", r.tone(tip))
+		}
+		p.printf("\n")
+	}
+
+	return p.err
+}
+
+// Plan prints the backlog items selected for a sprint and those deferred
+func (r *textRenderer) Plan(result models.PlanResult) error {
+	p := &printer{w: r.w}
+
+	p.printf("🗂️  Sprint Plan for %d points (max %d tasks)\n", result.TargetPoints, result.MaxTasks)
+	p.printf("═══════════════════════════════════════════════════\n")
+	p.printf("Selected %d tasks = %d of %d points\n\n", len(result.Selected), result.SelectedPoints, result.TargetPoints)
+
+	items, _ := planRows(result)
+	idWidth, sizeWidth := 0, 0
+	for _, item := range items {
+		idWidth = max(idWidth, len(item.ID))
+		sizeWidth = max(sizeWidth, len(item.Size))
+	}
+
+	printItem := func(item models.PlannedItem) {
+		line := fmt.Sprintf("%3d. %-*s  %-*s %3dpt  %s", item.Rank, idWidth, item.ID, sizeWidth, item.Size, item.Points, item.Title)
+		p.printf("%s\n", strings.TrimRight(line, " "))
+		if len(item.Requires) > 0 {
+			p.printf("       ↳ requires %s\n", strings.Join(item.Requires, " → "))
+		}
+		if item.Reason != "" {
+			p.printf("       ↳ %s\n", item.Reason)
+		}
+	}
+
+	p.printf("✅ Selected:\n")
+	if len(result.Selected) == 0 {
+		p.printf("  No backlog items fit %d points with max %d tasks\n", result.TargetPoints, result.MaxTasks)
+	}
+	for _, item := range result.Selected {
+		printItem(item)
+	}
+
+	if len(result.Deferred) > 0 {
+		p.printf("\n⏭️  Deferred:\n")
+		for _, item := range result.Deferred {
+			printItem(item)
+		}
+	}
+	p.printf("\n")
+
+	return p.err
+}
+
+// Velocity prints the recorded sprints and the velocity statistics derived from them
+func (r *textRenderer) Velocity(report models.VelocityReport) error {
+	p := &printer{w: r.w}
+
+	nameWidth := len("Sprint")
+	for _, record := range report.Sprints {
+		nameWidth = max(nameWidth, len(record.Sprint))
+	}
+
+	p.printf("📈 Velocity over %d sprint(s)\n", len(report.Sprints))
+	p.printf("═══════════════════════════════\n")
+	p.printf("%-*s  Planned  Completed\n", nameWidth, "Sprint")
+	for _, record := range report.Sprints {
+		p.printf("%-*s  %7d  %9d\n", nameWidth, record.Sprint, record.PlannedPoints, record.CompletedPoints)
+	}
+	p.printf("───────────────────────────────\n")
+	p.printf("%-16s %.1f points\n", "Average:", report.Average)
+	p.printf("%-16s %.1f points\n", "Median:", report.Median)
+	p.printf("%-16s %.1f points\n", fmt.Sprintf("Last %d sprints:", report.Window), report.RollingAverage)
+	p.printf("%-16s %+.1f points per sprint\n", "Trend:", report.Trend)
+	p.printf("%-16s %.0f%% of planned points\n", "Completion:", report.CompletionRate*100)
+
+	if len(report.PerSize) > 0 {
+		sizeWidth := 0
+		for _, size := range report.PerSize {
+			sizeWidth = max(sizeWidth, len(size.Size))
+		}
+
+		p.printf("\n📊 Tasks per Sprint by Size (planned → completed)\n")
+		for _, size := range report.PerSize {
+			p.printf("%-*s  %4.1f → %4.1f\n", sizeWidth, size.Size, size.AveragePlanned, size.AverageCompleted)
+		}
+	}
+
+	p.printf("\n%s\n", r.tone(fmt.Sprintf("💡 Use around %.0f points as the next target: sizely tasks %.0f", report.RollingAverage, report.RollingAverage)))
+	p.printf("\n")
+
+	return p.err
+}
+
+// Release prints the sprints and finish dates needed to complete a backlog per confidence level
+func (r *textRenderer) Release(forecast models.ReleaseForecast) error {
+	p := &printer{w: r.w}
+
+	p.printf("🚀 Release Forecast: %d tasks = %d points\n", forecast.TotalTasks, forecast.TotalPoints)
+	p.printf("═══════════════════════════════\n")
+	p.printf("Starting %s with %d-day sprints\n", forecast.Start, forecast.SprintLength)
+	p.printf("%d simulations from %d past sprint(s)\n", forecast.Trials, forecast.Sprints)
+	p.printf("───────────────────────────────\n")
+	p.printf("Confidence  Sprints  Finish\n")
+	for _, pct := range forecast.Percentiles {
+		p.printf("P%-9d  %7d  %s\n", pct.Confidence, pct.Sprints, pct.Finish)
+	}
+	p.printf("\n")
+
+	return p.err
+}
+
+// Config prints the effective configuration and where each value came from
+func (r *textRenderer) Config(cfg *config.Config) error {
+	p := &printer{w: r.w}
+	doc := newConfigDocument(cfg)
+
+	keyWidth, valueWidth := len("KEY"), len("VALUE")
+	for _, setting := range doc.Settings {
+		keyWidth = max(keyWidth, len(setting.Key))
+		valueWidth = max(valueWidth, len(setting.Value))
+	}
+
+	p.printf("⚙️  Effective Configuration\n")
+	p.printf("═══════════════════════════════\n")
+	p.printf("%-*s  %-*s  %s\n", keyWidth, "KEY", valueWidth, "VALUE", "SOURCE")
+	for _, setting := range doc.Settings {
+		p.printf("%-*s  %-*s  %s\n", keyWidth, setting.Key, valueWidth, setting.Value, setting.Source)
+	}
+
+	p.printf("───────────────────────────────\n")
+	if len(doc.Files) == 0 {
+		p.printf("No config files found (looked for %s and the user config)\n", config.ProjectFileName)
+	} else {
+		p.printf("Config files (lowest precedence first):\n")
+		for _, file := range doc.Files {
+			p.printf("  %s\n", file)
+		}
+	}
+	p.printf("\n")
+
+	return p.err
+}
+
+// commitmentSummary describes how the plan compares with the team's capacity
+func commitmentSummary(capacity models.TeamCapacity) string {
+	diff := float64(capacity.PlannedPoints) - capacity.CapacityPoints
+	switch capacity.Commitment {
+	case models.CommitmentOver:
+		return fmt.Sprintf("⚠️  Over-committed by %.1f points - consider deferring work", diff)
+	case models.CommitmentUnder:
+		return fmt.Sprintf("💤 Under-committed: %.1f points of spare capacity", -diff)
+	default:
+		return "✅ Commitment fits the team's capacity"
+	}
+}
+
+// forecastSummary describes how likely the sprint is to land
+func forecastSummary(forecast models.SprintForecast) string {
+	switch {
+	case forecast.Probability >= 0.85:
+		return "✅ Likely to land"
+	case forecast.Probability >= 0.5:
+		return "⚠️  Uncertain - trim the plan towards the higher-confidence points above"
+	default:
+		return "🚨 Unlikely to land - consider deferring work"
+	}
+}
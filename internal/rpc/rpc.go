@@ -0,0 +1,256 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net"
+	"time"
+
+	sizelyv1 "github.com/gr1m0h/sizely/api/sizely/v1"
+	"github.com/gr1m0h/sizely/internal/models"
+	"github.com/gr1m0h/sizely/pkg/sizely"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// MaxTasksLimit bounds max_tasks so a single call cannot search an unbounded number of combinations
+const MaxTasksLimit = 100
+
+// MaxPointsLimit bounds points so a single call cannot search an unbounded point range
+const MaxPointsLimit = 1000
+
+// MaxCombinationsLimit bounds the combinations a single call may return or stream
+const MaxCombinationsLimit = 10000
+
+// ShutdownTimeout is how long in-flight calls may take to finish after shutdown starts
+const ShutdownTimeout = 10 * time.Second
+
+// Server implements the SizelyService gRPC service with a sizely Estimator
+type Server struct {
+	sizelyv1.UnimplementedSizelyServiceServer
+
+	estimator *sizely.Estimator
+}
+
+// New creates the service backed by est; its max tasks is the default max_tasks of combination searches
+func New(est *sizely.Estimator) *Server {
+	return &Server{estimator: est}
+}
+
+// Register registers the service, and server reflection for tools such as grpcurl, on g
+func (s *Server) Register(g *grpc.Server) {
+	sizelyv1.RegisterSizelyServiceServer(g, s)
+	reflection.Register(g)
+}
+
+// Serve accepts connections on l until ctx is canceled, then stops gracefully, letting
+// in-flight calls finish within ShutdownTimeout
+func (s *Server) Serve(ctx context.Context, l net.Listener) error {
+	g := grpc.NewServer()
+	s.Register(g)
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- g.Serve(l)
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		g.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(ShutdownTimeout):
+		g.Stop()
+	}
+
+	// Serve reports ErrServerStopped when the server was stopped before it started serving
+	if err := <-errs; !errors.Is(err, grpc.ErrServerStopped) {
+		return err
+	}
+
+	return nil
+}
+
+// CalculatePoints returns the total points of the task counts
+func (s *Server) CalculatePoints(ctx context.Context, req *sizelyv1.CalculatePointsRequest) (*sizelyv1.CalculatePointsResponse, error) {
+	capacity, err := s.capacity(ctx, req.GetTasks())
+	if err != nil {
+		return nil, err
+	}
+
+	return &sizelyv1.CalculatePointsResponse{TotalPoints: int32(capacity.TotalPoints)}, nil
+}
+
+// CalculateSprintCapacity returns the per-size breakdown of the task counts
+func (s *Server) CalculateSprintCapacity(ctx context.Context, req *sizelyv1.CalculateSprintCapacityRequest) (*sizelyv1.CalculateSprintCapacityResponse, error) {
+	capacity, err := s.capacity(ctx, req.GetTasks())
+	if err != nil {
+		return nil, err
+	}
+
+	return &sizelyv1.CalculateSprintCapacityResponse{Capacity: capacityToProto(capacity)}, nil
+}
+
+// FindCombinations returns every combination of tasks adding up to the points
+func (s *Server) FindCombinations(ctx context.Context, req *sizelyv1.FindCombinationsRequest) (*sizelyv1.FindCombinationsResponse, error) {
+	points, est, err := s.search(req)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := est.Combinations(ctx, points)
+	if err != nil {
+		return nil, searchError(err)
+	}
+
+	resp := &sizelyv1.FindCombinationsResponse{
+		TargetPoints: int32(result.TargetPoints),
+		MaxTasks:     int32(result.MaxTasks),
+		Combinations: make([]*sizelyv1.Combination, len(result.Combinations)),
+		TotalFound:   int32(result.TotalFound),
+	}
+	for i, combo := range result.Combinations {
+		resp.Combinations[i] = combinationToProto(combo)
+	}
+
+	return resp, nil
+}
+
+// StreamCombinations sends the combinations of FindCombinations one message at a time as the
+// search finds them, stopping once the client cancels the stream
+func (s *Server) StreamCombinations(req *sizelyv1.FindCombinationsRequest, stream grpc.ServerStreamingServer[sizelyv1.Combination]) error {
+	points, est, err := s.search(req)
+	if err != nil {
+		return err
+	}
+
+	err = est.EachCombination(stream.Context(), points, func(combo models.Combination) error {
+		return stream.Send(combinationToProto(combo))
+	})
+
+	return searchError(err)
+}
+
+// capacity converts and validates the task counts of a request, returning their capacity
+func (s *Server) capacity(ctx context.Context, counts map[string]int32) (models.SprintCapacity, error) {
+	tasks := make(models.TaskCount, len(counts))
+	for name, count := range counts {
+		tasks[name] = int(count)
+	}
+
+	capacity, err := s.estimator.Capacity(ctx, tasks)
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return models.SprintCapacity{}, status.FromContextError(err).Err()
+	case err != nil:
+		return models.SprintCapacity{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if capacity.TotalPoints > math.MaxInt32 || capacity.TotalTasks > math.MaxInt32 {
+		return models.SprintCapacity{}, status.Error(codes.InvalidArgument, "task counts are too large")
+	}
+
+	return capacity, nil
+}
+
+// search validates a combination search, returning its points and an estimator bounded by
+// its max tasks and MaxCombinationsLimit
+func (s *Server) search(req *sizelyv1.FindCombinationsRequest) (int, *sizely.Estimator, error) {
+	if req.GetPoints() <= 0 {
+		return 0, nil, status.Errorf(codes.InvalidArgument, "points must be positive, got %d", req.GetPoints())
+	}
+	if req.GetPoints() > MaxPointsLimit {
+		return 0, nil, status.Errorf(codes.InvalidArgument, "points must be at most %d", MaxPointsLimit)
+	}
+
+	// The server's default is capped rather than rejected, like an omitted max_tasks over HTTP
+	maxTasks := min(s.estimator.MaxTasks(), MaxTasksLimit)
+	switch {
+	case req.GetMaxTasks() < 0:
+		return 0, nil, status.Errorf(codes.InvalidArgument, "max_tasks must not be negative, got %d", req.GetMaxTasks())
+	case req.GetMaxTasks() > MaxTasksLimit:
+		return 0, nil, status.Errorf(codes.InvalidArgument, "max_tasks must be at most %d", MaxTasksLimit)
+	case req.GetMaxTasks() > 0:
+		maxTasks = int(req.GetMaxTasks())
+	}
+
+	est, err := s.estimator.With(sizely.WithMaxTasks(maxTasks), sizely.WithCombinationLimit(MaxCombinationsLimit))
+	if err != nil {
+		return 0, nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return int(req.GetPoints()), est, nil
+}
+
+// searchError converts the error of a combination search to its status
+func searchError(err error) error {
+	switch {
+	case errors.Is(err, sizely.ErrTooManyCombinations):
+		return status.Errorf(codes.ResourceExhausted, "more than %d combinations found, lower points or max_tasks", MaxCombinationsLimit)
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	default:
+		return err
+	}
+}
+
+// capacityToProto converts a sprint capacity to its message
+func capacityToProto(capacity models.SprintCapacity) *sizelyv1.SprintCapacity {
+	msg := &sizelyv1.SprintCapacity{
+		TotalPoints: int32(capacity.TotalPoints),
+		TotalTasks:  int32(capacity.TotalTasks),
+		Breakdown:   make([]*sizelyv1.TaskBreakdown, len(capacity.Breakdown)),
+		Tasks:       countsToProto(capacity.Tasks),
+	}
+
+	for i, b := range capacity.Breakdown {
+		msg.Breakdown[i] = &sizelyv1.TaskBreakdown{
+			Size:   b.Size,
+			Count:  int32(b.Count),
+			Points: int32(b.Points),
+			Total:  int32(b.Total),
+		}
+	}
+
+	if effort := capacity.Effort; effort != nil {
+		msg.Effort = &sizelyv1.Effort{
+			MinHours:      effort.MinHours,
+			ExpectedHours: effort.ExpectedHours,
+			MaxHours:      effort.MaxHours,
+			StdDevHours:   effort.StdDevHours,
+			Unestimated:   effort.Unestimated,
+		}
+	}
+
+	return msg
+}
+
+// combinationToProto converts a combination to its message
+func combinationToProto(combo models.Combination) *sizelyv1.Combination {
+	return &sizelyv1.Combination{
+		Counts:     countsToProto(combo.Counts),
+		Points:     int32(combo.Points),
+		TotalTasks: int32(combo.TotalTasks()),
+	}
+}
+
+// countsToProto converts task counts to their map field
+func countsToProto(tasks models.TaskCount) map[string]int32 {
+	counts := make(map[string]int32, len(tasks))
+	for name, count := range tasks {
+		counts[name] = int32(count)
+	}
+
+	return counts
+}
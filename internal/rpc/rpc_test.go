@@ -0,0 +1,249 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	sizelyv1 "github.com/gr1m0h/sizely/api/sizely/v1"
+	"github.com/gr1m0h/sizely/pkg/sizely"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newServer creates the service backed by an estimator with opts
+func newServer(t *testing.T, opts ...sizely.Option) *Server {
+	t.Helper()
+
+	est, err := sizely.New(opts...)
+	require.NoError(t, err)
+
+	return New(est)
+}
+
+// newClient serves the service on an in-process connection and returns a client for it
+func newClient(t *testing.T) sizelyv1.SizelyServiceClient {
+	t.Helper()
+
+	return newClientFor(t)
+}
+
+// newClientFor is newClient for a service backed by an estimator with opts
+func newClientFor(t *testing.T, opts ...sizely.Option) sizelyv1.SizelyServiceClient {
+	t.Helper()
+
+	srv := newServer(t, opts...)
+
+	l := bufconn.Listen(1 << 20)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- srv.Serve(ctx, l)
+	}()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return l.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		conn.Close()
+		cancel()
+		assert.NoError(t, <-done)
+	})
+
+	return sizelyv1.NewSizelyServiceClient(conn)
+}
+
+func TestCalculatePoints(t *testing.T) {
+	client := newClient(t)
+	ctx := context.Background()
+
+	resp, err := client.CalculatePoints(ctx, &sizelyv1.CalculatePointsRequest{Tasks: map[string]int32{"xs": 3, "S": 2, "M": 1, "L": 1}})
+	require.NoError(t, err)
+	assert.Equal(t, int32(24), resp.GetTotalPoints())
+
+	tests := []struct {
+		name    string
+		tasks   map[string]int32
+		wantErr string
+	}{
+		{name: "Unknown size", tasks: map[string]int32{"XXL": 1}, wantErr: `unknown size "XXL"`},
+		{name: "Negative count", tasks: map[string]int32{"M": -1}, wantErr: "must not be negative"},
+		{name: "Overflow", tasks: map[string]int32{"L": 1 << 30}, wantErr: "too large"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.CalculatePoints(ctx, &sizelyv1.CalculatePointsRequest{Tasks: tt.tasks})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCalculateSprintCapacity(t *testing.T) {
+	resp, err := newClient(t).CalculateSprintCapacity(context.Background(), &sizelyv1.CalculateSprintCapacityRequest{Tasks: map[string]int32{"L": 1, "XS": 2}})
+	require.NoError(t, err)
+
+	capacity := resp.GetCapacity()
+	assert.Equal(t, int32(12), capacity.GetTotalPoints())
+	assert.Equal(t, int32(3), capacity.GetTotalTasks())
+	assert.Equal(t, map[string]int32{"XS": 2, "S": 0, "M": 0, "L": 1}, capacity.GetTasks())
+	require.Len(t, capacity.GetBreakdown(), 4)
+	assert.Equal(t, "L", capacity.GetBreakdown()[3].GetSize())
+	assert.Equal(t, int32(10), capacity.GetBreakdown()[3].GetTotal())
+	assert.Greater(t, capacity.GetEffort().GetExpectedHours(), 0.0)
+}
+
+func TestFindCombinations(t *testing.T) {
+	client := newClient(t)
+	ctx := context.Background()
+
+	resp, err := client.FindCombinations(ctx, &sizelyv1.FindCombinationsRequest{Points: 13, MaxTasks: 3})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), resp.GetTotalFound())
+	assert.Equal(t, int32(3), resp.GetMaxTasks())
+	assert.Equal(t, map[string]int32{"XS": 0, "S": 1, "M": 0, "L": 1}, resp.GetCombinations()[0].GetCounts())
+	assert.Equal(t, int32(2), resp.GetCombinations()[0].GetTotalTasks())
+
+	resp, err = client.FindCombinations(ctx, &sizelyv1.FindCombinationsRequest{Points: 13})
+	require.NoError(t, err)
+	assert.Equal(t, int32(15), resp.GetMaxTasks(), "zero max_tasks uses the server default")
+
+	tests := []struct {
+		name    string
+		req     *sizelyv1.FindCombinationsRequest
+		wantErr string
+	}{
+		{name: "Zero points", req: &sizelyv1.FindCombinationsRequest{}, wantErr: "points must be positive"},
+		{name: "Negative max tasks", req: &sizelyv1.FindCombinationsRequest{Points: 5, MaxTasks: -1}, wantErr: "must not be negative"},
+		{name: "Max tasks over limit", req: &sizelyv1.FindCombinationsRequest{Points: 5, MaxTasks: 1000}, wantErr: "at most 100"},
+		{name: "Points over limit", req: &sizelyv1.FindCombinationsRequest{Points: 1001}, wantErr: "points must be at most 1000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.FindCombinations(ctx, tt.req)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestStreamCombinations(t *testing.T) {
+	client := newClient(t)
+	ctx := context.Background()
+
+	unary, err := client.FindCombinations(ctx, &sizelyv1.FindCombinationsRequest{Points: 40, MaxTasks: 20})
+	require.NoError(t, err)
+
+	stream, err := client.StreamCombinations(ctx, &sizelyv1.FindCombinationsRequest{Points: 40, MaxTasks: 20})
+	require.NoError(t, err)
+
+	var streamed []*sizelyv1.Combination
+	for {
+		combo, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		streamed = append(streamed, combo)
+	}
+
+	// The stream sends combinations as they are found, so only the set matches
+	require.Len(t, streamed, int(unary.GetTotalFound()))
+	var want, got []map[string]int32
+	for i, combo := range streamed {
+		want = append(want, unary.GetCombinations()[i].GetCounts())
+		got = append(got, combo.GetCounts())
+	}
+	assert.ElementsMatch(t, want, got)
+
+	stream, err = client.StreamCombinations(ctx, &sizelyv1.FindCombinationsRequest{Points: -1})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestCombinationsLimit(t *testing.T) {
+	client := newClientFor(t, sizely.WithScalePreset("fibonacci"))
+	ctx := context.Background()
+	req := &sizelyv1.FindCombinationsRequest{Points: 200, MaxTasks: 100}
+
+	_, err := client.FindCombinations(ctx, req)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.ErrorContains(t, err, "more than 10000 combinations found")
+
+	stream, err := client.StreamCombinations(ctx, req)
+	require.NoError(t, err)
+	received := 0
+	for {
+		if _, err = stream.Recv(); err != nil {
+			break
+		}
+		received++
+	}
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, MaxCombinationsLimit, received)
+}
+
+func TestFindCombinationsDefaultMaxTasks(t *testing.T) {
+	client := newClientFor(t, sizely.WithMaxTasks(500))
+
+	resp, err := client.FindCombinations(context.Background(), &sizelyv1.FindCombinationsRequest{Points: 13})
+	require.NoError(t, err)
+	assert.Equal(t, int32(MaxTasksLimit), resp.GetMaxTasks(), "the server default is capped at the limit")
+}
+
+// canceledStream is a combination stream whose client has gone away
+type canceledStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent int
+}
+
+func (s *canceledStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *canceledStream) Send(*sizelyv1.Combination) error {
+	s.sent++
+	return nil
+}
+
+func TestStreamCombinationsCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	stream := &canceledStream{ctx: ctx}
+
+	err := newServer(t).StreamCombinations(&sizelyv1.FindCombinationsRequest{Points: 40, MaxTasks: 20}, stream)
+	assert.Equal(t, codes.Canceled, status.Code(err))
+	assert.Zero(t, stream.sent)
+}
+
+func TestServeStopsOnCancel(t *testing.T) {
+	srv := newServer(t)
+	l := bufconn.Listen(1 << 20)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- srv.Serve(ctx, l)
+	}()
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not stop")
+	}
+}
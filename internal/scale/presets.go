@@ -0,0 +1,104 @@
+package scale
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// presets holds the built-in scales; every preset reuses T-shirt names so the same
+// task counts can be compared across conventions
+var presets = map[string][]Size{
+	"tshirt": Default().Sizes,
+	"fibonacci": {
+		{Name: "XS", Points: 1},
+		{Name: "S", Points: 2},
+		{Name: "M", Points: 3},
+		{Name: "L", Points: 5},
+		{Name: "XL", Points: 8},
+		{Name: "XXL", Points: 13},
+		{Name: "XXXL", Points: 21},
+	},
+	"pow2": {
+		{Name: "XS", Points: 1},
+		{Name: "S", Points: 2},
+		{Name: "M", Points: 4},
+		{Name: "L", Points: 8},
+		{Name: "XL", Points: 16},
+		{Name: "XXL", Points: 32},
+	},
+	"linear": {
+		{Name: "XS", Points: 1},
+		{Name: "S", Points: 2},
+		{Name: "M", Points: 3},
+		{Name: "L", Points: 4},
+		{Name: "XL", Points: 5},
+	},
+}
+
+// PresetNames returns the names of the built-in scales in alphabetical order
+func PresetNames() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Preset returns the built-in scale with the given name
+func Preset(name string) (Scale, error) {
+	sizes, ok := presets[strings.ToLower(name)]
+	if !ok {
+		return Scale{}, fmt.Errorf("unknown scale preset %q (available: %s)", name, strings.Join(PresetNames(), ", "))
+	}
+
+	return Scale{Name: strings.ToLower(name), Sizes: append([]Size(nil), sizes...)}, nil
+}
+
+// UnmarshalYAML accepts either a preset name or a full scale definition
+func (s *Scale) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		preset, err := Preset(node.Value)
+		if err != nil {
+			return err
+		}
+		*s = preset
+		return nil
+	}
+
+	type plain Scale
+	var p plain
+	if err := node.Decode(&p); err != nil {
+		return err
+	}
+	*s = Scale(p)
+
+	return nil
+}
+
+// UnmarshalJSON accepts either a preset name or a full scale definition
+func (s *Scale) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		preset, err := Preset(name)
+		if err != nil {
+			return err
+		}
+		*s = preset
+		return nil
+	}
+
+	type plain Scale
+	var p plain
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	*s = Scale(p)
+
+	return nil
+}
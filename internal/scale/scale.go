@@ -0,0 +1,222 @@
+package scale
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Size represents a single named size and its point value
+type Size struct {
+	Name   string `json:"name" yaml:"name"`
+	Points int    `json:"points" yaml:"points"`
+	Hours  *Hours `json:"hours,omitempty" yaml:"hours,omitempty"`
+}
+
+// Hours represents how long a task of one size takes, as a three-point estimate in hours
+type Hours struct {
+	Min    float64 `json:"min" yaml:"min"`
+	Likely float64 `json:"likely,omitempty" yaml:"likely,omitempty"`
+	Max    float64 `json:"max" yaml:"max"`
+}
+
+// MostLikely returns the most likely duration, the midpoint of Min and Max when Likely is unset
+func (h Hours) MostLikely() float64 {
+	if h.Likely == 0 {
+		return (h.Min + h.Max) / 2
+	}
+
+	return h.Likely
+}
+
+// Expected returns the PERT mean (min + 4 × likely + max) / 6
+func (h Hours) Expected() float64 {
+	return (h.Min + 4*h.MostLikely() + h.Max) / 6
+}
+
+// StdDev returns the PERT standard deviation (max - min) / 6
+func (h Hours) StdDev() float64 {
+	return (h.Max - h.Min) / 6
+}
+
+// Validate checks that the range is non-negative, ordered and contains the most likely duration
+func (h Hours) Validate() error {
+	if h.Min < 0 || h.Max <= 0 {
+		return fmt.Errorf("hours must be positive")
+	}
+	if h.Min > h.Max {
+		return fmt.Errorf("hours min %g exceeds max %g", h.Min, h.Max)
+	}
+	if h.Likely != 0 && (h.Likely < h.Min || h.Likely > h.Max) {
+		return fmt.Errorf("hours likely %g must lie between min %g and max %g", h.Likely, h.Min, h.Max)
+	}
+
+	return nil
+}
+
+// Scale represents an ordered set of sizes, from smallest to largest
+type Scale struct {
+	Name  string `json:"name,omitempty" yaml:"name,omitempty"`
+	Sizes []Size `json:"sizes" yaml:"sizes"`
+}
+
+// Default returns the classic T-shirt scale (XS=1, S=3, M=5, L=10) with its time ranges:
+// XS 30 minutes - 4 hours, S 4 hours - 1 day, M 2-3 days and L about a week of 8-hour days
+func Default() Scale {
+	return Scale{
+		Name: "tshirt",
+		Sizes: []Size{
+			{Name: "XS", Points: 1, Hours: &Hours{Min: 0.5, Likely: 2, Max: 4}},
+			{Name: "S", Points: 3, Hours: &Hours{Min: 4, Likely: 6, Max: 8}},
+			{Name: "M", Points: 5, Hours: &Hours{Min: 16, Likely: 20, Max: 24}},
+			{Name: "L", Points: 10, Hours: &Hours{Min: 32, Likely: 40, Max: 48}},
+		},
+	}
+}
+
+// New creates a validated scale with sizes ordered by ascending points
+func New(name string, sizes []Size) (Scale, error) {
+	s := Scale{Name: name, Sizes: append([]Size(nil), sizes...)}
+	sort.SliceStable(s.Sizes, func(i, j int) bool {
+		return s.Sizes[i].Points < s.Sizes[j].Points
+	})
+
+	if err := s.Validate(); err != nil {
+		return Scale{}, err
+	}
+
+	return s, nil
+}
+
+// Load reads a scale definition from a YAML or JSON file
+func Load(filename string) (Scale, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return Scale{}, fmt.Errorf("reading scale file: %w", err)
+	}
+
+	s, err := Parse(data, filepath.Ext(filename))
+	if err != nil {
+		return Scale{}, fmt.Errorf("loading scale %s: %w", filename, err)
+	}
+
+	return s, nil
+}
+
+// Parse decodes a scale definition; ext selects JSON (".json") or YAML (anything else)
+func Parse(data []byte, ext string) (Scale, error) {
+	var s Scale
+
+	if strings.EqualFold(ext, ".json") {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return Scale{}, fmt.Errorf("parsing JSON: %w", err)
+		}
+	} else {
+		if err := yaml.Unmarshal(data, &s); err != nil {
+			return Scale{}, fmt.Errorf("parsing YAML: %w", err)
+		}
+	}
+
+	return New(s.Name, s.Sizes)
+}
+
+// Validate checks that the scale has uniquely named sizes with positive points
+func (s Scale) Validate() error {
+	if len(s.Sizes) == 0 {
+		return fmt.Errorf("scale must define at least one size")
+	}
+
+	seen := make(map[string]bool, len(s.Sizes))
+	for _, size := range s.Sizes {
+		if strings.TrimSpace(size.Name) == "" {
+			return fmt.Errorf("size name must not be empty")
+		}
+		if size.Points <= 0 {
+			return fmt.Errorf("size %s: points must be positive", size.Name)
+		}
+		if size.Hours != nil {
+			if err := size.Hours.Validate(); err != nil {
+				return fmt.Errorf("size %s: %w", size.Name, err)
+			}
+		}
+
+		key := strings.ToUpper(size.Name)
+		if seen[key] {
+			return fmt.Errorf("duplicate size %s", size.Name)
+		}
+		seen[key] = true
+	}
+
+	return nil
+}
+
+// HasHours reports whether any size of the scale defines a time range
+func (s Scale) HasHours() bool {
+	for _, size := range s.Sizes {
+		if size.Hours != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Lookup finds a size by name, ignoring case
+func (s Scale) Lookup(name string) (Size, bool) {
+	for _, size := range s.Sizes {
+		if strings.EqualFold(size.Name, name) {
+			return size, true
+		}
+	}
+
+	return Size{}, false
+}
+
+// Names returns the size names from smallest to largest
+func (s Scale) Names() []string {
+	names := make([]string, len(s.Sizes))
+	for i, size := range s.Sizes {
+		names[i] = size.Name
+	}
+
+	return names
+}
+
+// Descending returns the sizes from largest to smallest
+func (s Scale) Descending() []Size {
+	sizes := make([]Size, len(s.Sizes))
+	for i, size := range s.Sizes {
+		sizes[len(s.Sizes)-1-i] = size
+	}
+
+	return sizes
+}
+
+// Largest returns the size with the highest point value
+func (s Scale) Largest() Size {
+	return s.Sizes[len(s.Sizes)-1]
+}
+
+// Small returns the lower half of the scale, the sizes considered quick wins
+func (s Scale) Small() []Size {
+	return s.Sizes[:len(s.Sizes)/2]
+}
+
+// String returns a compact description such as "tshirt (XS=1, S=3, M=5, L=10)"
+func (s Scale) String() string {
+	parts := make([]string, len(s.Sizes))
+	for i, size := range s.Sizes {
+		parts[i] = fmt.Sprintf("%s=%d", size.Name, size.Points)
+	}
+
+	if s.Name == "" {
+		return strings.Join(parts, ", ")
+	}
+
+	return fmt.Sprintf("%s (%s)", s.Name, strings.Join(parts, ", "))
+}
@@ -0,0 +1,135 @@
+package scale
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	s, err := New("custom", []Size{
+		{Name: "L", Points: 8},
+		{Name: "S", Points: 2},
+		{Name: "M", Points: 5},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"S", "M", "L"}, s.Names())
+	assert.Equal(t, "L", s.Largest().Name)
+	assert.Equal(t, []Size{{Name: "S", Points: 2}}, s.Small())
+	assert.Equal(t, "L", s.Descending()[0].Name)
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		sizes   []Size
+		wantErr string
+	}{
+		{name: "Empty scale", sizes: nil, wantErr: "at least one size"},
+		{name: "Missing name", sizes: []Size{{Name: " ", Points: 1}}, wantErr: "must not be empty"},
+		{name: "Zero points", sizes: []Size{{Name: "XS", Points: 0}}, wantErr: "points must be positive"},
+		{name: "Duplicate name", sizes: []Size{{Name: "XS", Points: 1}, {Name: "xs", Points: 2}}, wantErr: "duplicate size"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New("invalid", tt.sizes)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	t.Run("YAML", func(t *testing.T) {
+		s, err := Parse([]byte("name: team\nsizes:\n  - {name: XL, points: 20}\n  - {name: M, points: 5}\n"), ".yaml")
+		require.NoError(t, err)
+
+		assert.Equal(t, "team", s.Name)
+		assert.Equal(t, []string{"M", "XL"}, s.Names())
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		s, err := Parse([]byte(`{"sizes":[{"name":"XS","points":1},{"name":"XXL","points":21}]}`), ".json")
+		require.NoError(t, err)
+
+		size, ok := s.Lookup("xxl")
+		assert.True(t, ok)
+		assert.Equal(t, 21, size.Points)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := Parse([]byte(`{"sizes":[]}`), ".json")
+		assert.Error(t, err)
+	})
+}
+
+func TestPresets(t *testing.T) {
+	assert.Equal(t, []string{"fibonacci", "linear", "pow2", "tshirt"}, PresetNames())
+
+	for _, name := range PresetNames() {
+		t.Run(name, func(t *testing.T) {
+			s, err := Preset(name)
+			require.NoError(t, err)
+			assert.NoError(t, s.Validate())
+			assert.Equal(t, name, s.Name)
+			assert.Equal(t, "XS", s.Sizes[0].Name)
+		})
+	}
+
+	fib, err := Preset("Fibonacci")
+	require.NoError(t, err)
+	assert.Equal(t, "fibonacci (XS=1, S=2, M=3, L=5, XL=8, XXL=13, XXXL=21)", fib.String())
+
+	tshirt, err := Preset("tshirt")
+	require.NoError(t, err)
+	assert.Equal(t, Default(), tshirt)
+
+	_, err = Preset("planning-poker")
+	assert.ErrorContains(t, err, "available: fibonacci, linear, pow2, tshirt")
+}
+
+func TestParsePresetName(t *testing.T) {
+	s, err := Parse([]byte("pow2\n"), ".yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "pow2", s.Name)
+
+	s, err = Parse([]byte(`"linear"`), ".json")
+	require.NoError(t, err)
+	assert.Equal(t, 5, s.Largest().Points)
+}
+
+func TestHours(t *testing.T) {
+	h := Hours{Min: 2, Max: 8}
+	assert.Equal(t, 5.0, h.MostLikely())
+	assert.Equal(t, 5.0, h.Expected())
+	assert.Equal(t, 1.0, h.StdDev())
+
+	h.Likely = 3
+	assert.InDelta(t, 22.0/6, h.Expected(), 1e-9)
+
+	s, err := Parse([]byte("sizes:\n  - {name: S, points: 1, hours: {min: 1, likely: 2, max: 4}}\n  - {name: L, points: 5}\n"), ".yaml")
+	require.NoError(t, err)
+	assert.True(t, s.HasHours())
+	assert.Equal(t, &Hours{Min: 1, Likely: 2, Max: 4}, s.Sizes[0].Hours)
+	assert.Nil(t, s.Sizes[1].Hours)
+
+	tests := []struct {
+		name    string
+		hours   Hours
+		wantErr string
+	}{
+		{name: "Negative", hours: Hours{Min: -1, Max: 2}, wantErr: "must be positive"},
+		{name: "Zero max", hours: Hours{}, wantErr: "must be positive"},
+		{name: "Reversed", hours: Hours{Min: 4, Max: 2}, wantErr: "exceeds max"},
+		{name: "Likely outside", hours: Hours{Min: 1, Likely: 5, Max: 4}, wantErr: "must lie between"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New("invalid", []Size{{Name: "S", Points: 1, Hours: &tt.hours}})
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
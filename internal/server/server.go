@@ -0,0 +1,226 @@
+package server
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gr1m0h/sizely/internal/models"
+	"github.com/gr1m0h/sizely/pkg/sizely"
+)
+
+// MaxBodyBytes limits the size of request bodies
+const MaxBodyBytes = 1 << 20
+
+// MaxTasksLimit bounds max_tasks so a single request cannot search an unbounded number of combinations
+const MaxTasksLimit = 100
+
+// MaxPointsLimit bounds points so a single request cannot search an unbounded point range
+const MaxPointsLimit = 1000
+
+// MaxCombinationsLimit bounds the combinations a single request may return
+const MaxCombinationsLimit = 10000
+
+// SearchTimeout is how long a single combination search may run
+const SearchTimeout = 5 * time.Second
+
+// ShutdownTimeout is how long in-flight requests may take to finish after shutdown starts
+const ShutdownTimeout = 10 * time.Second
+
+//go:embed openapi.json
+var openAPI []byte
+
+// Server serves the sizely calculations as a JSON API
+type Server struct {
+	estimator *sizely.Estimator
+	mux       *http.ServeMux
+}
+
+// errorBody is the JSON body of every error response
+type errorBody struct {
+	Error string `json:"error"`
+}
+
+// New creates a server backed by est; its max tasks is the default max_tasks of combination searches
+func New(est *sizely.Estimator) *Server {
+	s := &Server{estimator: est, mux: http.NewServeMux()}
+
+	s.mux.HandleFunc("/v1/points", s.method(http.MethodPost, s.handlePoints))
+	s.mux.HandleFunc("/v1/combinations", s.method(http.MethodGet, s.handleCombinations))
+	s.mux.HandleFunc("/openapi.json", s.method(http.MethodGet, s.handleOpenAPI))
+	s.mux.HandleFunc("/healthz", s.method(http.MethodGet, func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	}))
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no such endpoint: %s", r.URL.Path))
+	})
+
+	return s
+}
+
+// ServeHTTP routes a request to its endpoint
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Serve accepts connections on l until ctx is canceled, then shuts down gracefully,
+// letting in-flight requests finish within ShutdownTimeout
+func (s *Server) Serve(ctx context.Context, l net.Listener) error {
+	srv := &http.Server{Handler: s, ReadHeaderTimeout: 10 * time.Second}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- srv.Serve(l)
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), ShutdownTimeout)
+		defer cancel()
+
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			return fmt.Errorf("shutting down: %w", err)
+		}
+		if err := <-errs; !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}
+}
+
+// method rejects requests with any other method than the endpoint's
+func (s *Server) method(method string, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != method {
+			w.Header().Set("Allow", method)
+			writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("method %s not allowed, use %s", r.Method, method))
+			return
+		}
+		handler(w, r)
+	}
+}
+
+// handlePoints calculates the sprint capacity of the task counts in the request body
+func (s *Server) handlePoints(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxBodyBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("request body exceeds %d bytes", MaxBodyBytes))
+			return
+		}
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("reading request body: %v", err))
+		return
+	}
+
+	var tasks models.TaskCount
+	if err := json.Unmarshal(body, &tasks); err != nil || tasks == nil {
+		writeError(w, http.StatusBadRequest, `request body must be a JSON object of task counts per size, e.g. {"S": 2, "M": 1}`)
+		return
+	}
+
+	capacity, err := s.estimator.Capacity(r.Context(), tasks)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, capacity)
+}
+
+// handleCombinations finds the task combinations for the points and max_tasks query parameters
+func (s *Server) handleCombinations(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	points, err := positiveParam(query.Get("points"), "points")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if points > MaxPointsLimit {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("points must be at most %d", MaxPointsLimit))
+		return
+	}
+
+	// The server's default is capped rather than rejected, so a large configured max_tasks
+	// does not fail every request leaving it out
+	maxTasks := min(s.estimator.MaxTasks(), MaxTasksLimit)
+	if value := query.Get("max_tasks"); value != "" {
+		if maxTasks, err = positiveParam(value, "max_tasks"); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if maxTasks > MaxTasksLimit {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("max_tasks must be at most %d", MaxTasksLimit))
+			return
+		}
+	}
+
+	est, err := s.estimator.With(sizely.WithMaxTasks(maxTasks), sizely.WithCombinationLimit(MaxCombinationsLimit))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// The search stops once the client goes away, it runs out of time or finds too many
+	// combinations to send
+	ctx, cancel := context.WithTimeout(r.Context(), SearchTimeout)
+	defer cancel()
+
+	result, err := est.Combinations(ctx, points)
+	switch {
+	case errors.Is(err, sizely.ErrTooManyCombinations):
+		writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("more than %d combinations found, lower points or max_tasks", MaxCombinationsLimit))
+		return
+	case errors.Is(err, context.DeadlineExceeded):
+		writeError(w, http.StatusServiceUnavailable, fmt.Sprintf("search took longer than %s, lower points or max_tasks", SearchTimeout))
+		return
+	case err != nil:
+		writeError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// handleOpenAPI serves the OpenAPI document describing the API
+func (s *Server) handleOpenAPI(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(openAPI)
+}
+
+// positiveParam parses a required positive integer query parameter
+func positiveParam(value, name string) (int, error) {
+	if value == "" {
+		return 0, fmt.Errorf("query parameter %s is required", name)
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("query parameter %s must be a positive integer, got %q", name, value)
+	}
+
+	return n, nil
+}
+
+// writeJSON writes v as the JSON response body with status
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes a JSON error body with status
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorBody{Error: message})
+}
@@ -0,0 +1,199 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gr1m0h/sizely/internal/models"
+	"github.com/gr1m0h/sizely/pkg/sizely"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T, opts ...sizely.Option) *Server {
+	t.Helper()
+
+	est, err := sizely.New(opts...)
+	require.NoError(t, err)
+
+	return New(est)
+}
+
+func TestPoints(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		body      string
+		status    int
+		wantError string
+	}{
+		{name: "Valid counts", method: http.MethodPost, body: `{"xs": 3, "S": 2, "M": 1, "L": 1}`, status: http.StatusOK},
+		{name: "Unknown size", method: http.MethodPost, body: `{"XXL": 1}`, status: http.StatusBadRequest, wantError: `unknown size "XXL"`},
+		{name: "Negative count", method: http.MethodPost, body: `{"M": -1}`, status: http.StatusBadRequest, wantError: "must not be negative"},
+		{name: "Not an object", method: http.MethodPost, body: `[1, 2]`, status: http.StatusBadRequest, wantError: "JSON object of task counts"},
+		{name: "Empty body", method: http.MethodPost, body: ``, status: http.StatusBadRequest, wantError: "JSON object of task counts"},
+		{name: "Too large", method: http.MethodPost, body: `{"M": 1, "pad": "` + strings.Repeat("x", MaxBodyBytes) + `"}`, status: http.StatusRequestEntityTooLarge, wantError: "exceeds"},
+		{name: "Wrong method", method: http.MethodGet, status: http.StatusMethodNotAllowed, wantError: "use POST"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			newTestServer(t).ServeHTTP(rec, httptest.NewRequest(tt.method, "/v1/points", strings.NewReader(tt.body)))
+
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+			if tt.wantError != "" {
+				var body errorBody
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				assert.Contains(t, body.Error, tt.wantError)
+				return
+			}
+
+			var capacity models.SprintCapacity
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &capacity))
+			assert.Equal(t, 24, capacity.TotalPoints)
+			assert.Equal(t, 7, capacity.TotalTasks)
+			assert.Equal(t, 3, capacity.Tasks["XS"])
+		})
+	}
+}
+
+func TestCombinations(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		status    int
+		maxTasks  int
+		found     int
+		wantError string
+	}{
+		{name: "Default max tasks", query: "points=13", status: http.StatusOK, maxTasks: 15, found: 12},
+		{name: "Explicit max tasks", query: "points=13&max_tasks=3", status: http.StatusOK, maxTasks: 3, found: 2},
+		{name: "Missing points", query: "", status: http.StatusBadRequest, wantError: "points is required"},
+		{name: "Invalid points", query: "points=abc", status: http.StatusBadRequest, wantError: "positive integer"},
+		{name: "Zero max tasks", query: "points=5&max_tasks=0", status: http.StatusBadRequest, wantError: "positive integer"},
+		{name: "Max tasks over limit", query: "points=5&max_tasks=1000", status: http.StatusBadRequest, wantError: "at most 100"},
+		{name: "Points over limit", query: "points=1001", status: http.StatusBadRequest, wantError: "points must be at most 1000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			newTestServer(t).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/combinations?"+tt.query, nil))
+
+			assert.Equal(t, tt.status, rec.Code)
+			if tt.wantError != "" {
+				var body errorBody
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				assert.Contains(t, body.Error, tt.wantError)
+				return
+			}
+
+			var result models.CombinationResult
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+			assert.Equal(t, 13, result.TargetPoints)
+			assert.Equal(t, tt.maxTasks, result.MaxTasks)
+			assert.Equal(t, tt.found, result.TotalFound)
+		})
+	}
+}
+
+func TestCombinationsLimits(t *testing.T) {
+	srv := newTestServer(t, sizely.WithScalePreset("fibonacci"))
+
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/combinations?points=200&max_tasks=100", nil))
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.JSONEq(t, `{"error": "more than 10000 combinations found, lower points or max_tasks"}`, rec.Body.String())
+
+	// A client that went away stops the search
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rec = httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/combinations?points=13", nil).WithContext(ctx))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	// A search running out of time is answered rather than left running
+	ctx, cancel = context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	rec = httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/combinations?points=13", nil).WithContext(ctx))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Contains(t, rec.Body.String(), "search took longer than 5s")
+}
+
+func TestCombinationsDefaultMaxTasks(t *testing.T) {
+	// A configured max_tasks above the limit is capped for requests leaving it out
+	srv := newTestServer(t, sizely.WithMaxTasks(500))
+
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/combinations?points=13", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var result models.CombinationResult
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	assert.Equal(t, MaxTasksLimit, result.MaxTasks)
+
+	rec = httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/combinations?points=13&max_tasks=101", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestOpenAPIAndRouting(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newTestServer(t).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var doc struct {
+		OpenAPI string         `json:"openapi"`
+		Paths   map[string]any `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+	assert.Equal(t, "3.0.3", doc.OpenAPI)
+	assert.Contains(t, doc.Paths, "/v1/points")
+	assert.Contains(t, doc.Paths, "/v1/combinations")
+
+	rec = httptest.NewRecorder()
+	newTestServer(t).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v2/points", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.JSONEq(t, `{"error": "no such endpoint: /v2/points"}`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	newTestServer(t).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/combinations?points=5", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(t, http.MethodGet, rec.Header().Get("Allow"))
+}
+
+func TestServeShutsDownGracefully(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- newTestServer(t).Serve(ctx, l)
+	}()
+
+	resp, err := http.Get("http://" + l.Addr().String() + "/healthz")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not shut down")
+	}
+
+	_, err = http.Get("http://" + l.Addr().String() + "/healthz")
+	assert.Error(t, err, "the listener is closed after shutdown")
+}
@@ -0,0 +1,149 @@
+package sizely
+
+import (
+	"flag"
+	"fmt"
+	"go/importer"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "rewrite testdata/api.txt with the current API")
+
+// modulePath is the module whose types are part of the API when reachable from the package
+const modulePath = "github.com/gr1m0h/sizely"
+
+// TestAPICompatibility fails on any change to the exported API, including the fields of the
+// types it aliases. Additions are compatible: review them and record them with -update.
+// Changes and removals break users and need a new major version.
+func TestAPICompatibility(t *testing.T) {
+	pkg, err := importer.ForCompiler(token.NewFileSet(), "source", nil).Import(modulePath + "/pkg/sizely")
+	require.NoError(t, err)
+
+	api := describeAPI(pkg)
+	golden := filepath.Join("testdata", "api.txt")
+	if *update {
+		require.NoError(t, os.WriteFile(golden, []byte(api), 0o644))
+	}
+
+	want, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), api, "the exported API changed; if only additions, run go test ./pkg/sizely -update")
+}
+
+// describeAPI lists the exported declarations of pkg followed by the exported fields and
+// methods of every type of the module they reach, one sorted line each
+func describeAPI(pkg *types.Package) string {
+	qualifier := func(p *types.Package) string { return p.Name() }
+
+	var lines []string
+	seen := map[*types.TypeName]bool{}
+	var queue []*types.Named
+
+	var reach func(t types.Type)
+	reach = func(t types.Type) {
+		switch t := types.Unalias(t).(type) {
+		case *types.Named:
+			obj := t.Obj()
+			if obj.Pkg() == nil || !strings.HasPrefix(obj.Pkg().Path(), modulePath) || seen[obj] {
+				return
+			}
+			seen[obj] = true
+			queue = append(queue, t)
+		case *types.Pointer:
+			reach(t.Elem())
+		case *types.Slice:
+			reach(t.Elem())
+		case *types.Array:
+			reach(t.Elem())
+		case *types.Map:
+			reach(t.Key())
+			reach(t.Elem())
+		case *types.Signature:
+			for i := range t.Params().Len() {
+				reach(t.Params().At(i).Type())
+			}
+			for i := range t.Results().Len() {
+				reach(t.Results().At(i).Type())
+			}
+		}
+	}
+
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		obj := scope.Lookup(name)
+		if !obj.Exported() {
+			continue
+		}
+
+		switch obj := obj.(type) {
+		case *types.TypeName:
+			if obj.IsAlias() {
+				lines = append(lines, types.ObjectString(obj, qualifier))
+			} else {
+				lines = append(lines, fmt.Sprintf("type %s", types.TypeString(obj.Type(), qualifier)))
+			}
+		case *types.Const:
+			lines = append(lines, fmt.Sprintf("%s = %s", types.ObjectString(obj, qualifier), obj.Val().ExactString()))
+		default:
+			lines = append(lines, types.ObjectString(obj, qualifier))
+		}
+		reach(obj.Type())
+	}
+
+	for len(queue) > 0 {
+		named := queue[0]
+		queue = queue[1:]
+		name := types.TypeString(named, qualifier)
+
+		switch underlying := named.Underlying().(type) {
+		case *types.Struct:
+			for i := range underlying.NumFields() {
+				field := underlying.Field(i)
+				if !field.Exported() {
+					continue
+				}
+				lines = append(lines, strings.TrimSpace(fmt.Sprintf("field %s.%s %s %s", name, field.Name(),
+					types.TypeString(field.Type(), qualifier), jsonTag(underlying.Tag(i)))))
+				reach(field.Type())
+			}
+		default:
+			lines = append(lines, fmt.Sprintf("underlying %s %s", name, types.TypeString(underlying, qualifier)))
+			reach(underlying)
+		}
+
+		methods := types.NewMethodSet(types.NewPointer(named))
+		for i := range methods.Len() {
+			method := methods.At(i).Obj()
+			if !method.Exported() {
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("method %s.%s%s", name, method.Name(),
+				strings.TrimPrefix(types.TypeString(method.Type(), qualifier), "func")))
+			reach(method.Type())
+		}
+	}
+
+	sort.Strings(lines)
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// jsonTag returns the json struct tag, which is part of the encoded API
+func jsonTag(tag string) string {
+	value, ok := reflect.StructTag(tag).Lookup("json")
+	if !ok {
+		return ""
+	}
+
+	return fmt.Sprintf("json:%q", value)
+}
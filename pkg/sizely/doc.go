@@ -0,0 +1,30 @@
+// Package sizely estimates sprints from T-shirt sized tasks: it calculates the points of task
+// counts, finds the task combinations adding up to a point target, plans sprints from a
+// prioritized backlog and forecasts delivery from the velocity history. It is the library
+// behind the sizely command.
+//
+// An Estimator is configured with functional options, and its calculations are safe for
+// concurrent use:
+//
+//	est, err := sizely.New(sizely.WithScalePreset("fibonacci"), sizely.WithMaxTasks(5))
+//	if err != nil {
+//		return err
+//	}
+//	result, err := est.Combinations(ctx, 13)
+//
+// Every calculation takes a context. The searches and simulations, which may run for long,
+// check it while they run and stop with its error once it is done: Combinations,
+// EachCombination, Plan, ForecastSprint and ForecastRelease. The other calculations take time
+// linear in their input and only check the context before they start.
+//
+// An Estimator used to serve requests can derive a copy per request with With, for example to
+// bound a search with WithMaxTasks and WithCombinationLimit.
+//
+// # Compatibility
+//
+// The package follows semantic versioning: within a major version, exported identifiers,
+// struct fields and method signatures are only ever added, never changed or removed. The
+// exported API is recorded in testdata/api.txt and checked by the package tests, so a
+// breaking change cannot be made without deliberately updating that record for a new
+// major version.
+package sizely
@@ -0,0 +1,35 @@
+package sizely_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/gr1m0h/sizely/pkg/sizely"
+)
+
+func Example() {
+	est, err := sizely.New(sizely.WithScalePreset("fibonacci"), sizely.WithMaxTasks(2))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	points, err := est.Points(context.Background(), sizely.TaskCount{"S": 2, "L": 1})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(points, "points")
+
+	result, err := est.Combinations(context.Background(), 8)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, combo := range result.Combinations {
+		fmt.Println(combo.TotalTasks(), "tasks:", combo.Counts["XL"], "XL,", combo.Counts["L"], "L,", combo.Counts["M"], "M")
+	}
+
+	// Output:
+	// 9 points
+	// 1 tasks: 1 XL, 0 L, 0 M
+	// 2 tasks: 0 XL, 1 L, 1 M
+}
@@ -0,0 +1,352 @@
+package sizely
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"time"
+
+	"github.com/gr1m0h/sizely/internal/calculator"
+	"github.com/gr1m0h/sizely/internal/config"
+	"github.com/gr1m0h/sizely/internal/render"
+	"github.com/gr1m0h/sizely/internal/scale"
+)
+
+// DefaultMaxTasks is the maximum number of tasks of a combination or plan unless WithMaxTasks is given
+const DefaultMaxTasks = 15
+
+// DefaultTrials is the number of simulated sprints or releases a forecast usually runs
+const DefaultTrials = calculator.DefaultTrials
+
+// DefaultVelocityWindow is the number of recent sprints a velocity report usually averages
+const DefaultVelocityWindow = calculator.DefaultVelocityWindow
+
+// ErrTooManyCombinations is reported when a search finds more combinations than WithCombinationLimit allows
+var ErrTooManyCombinations = calculator.ErrTooManyCombinations
+
+// Estimator calculates sprint estimates on one scale and writes them in one output format
+type Estimator struct {
+	settings    settings
+	calculator  *calculator.Calculator
+	renderer    render.Renderer
+	constraints Constraints
+}
+
+// settings collects the options of New
+type settings struct {
+	scale       Scale
+	maxTasks    int
+	limit       int
+	constraints Constraints
+	w           io.Writer
+	format      string
+	template    string
+	style       Style
+	advice      Advice
+}
+
+// Option configures an Estimator
+type Option func(*settings) error
+
+// WithScale estimates in the sizes of s instead of the default T-shirt scale; the sizes may
+// be given in any order
+func WithScale(s Scale) Option {
+	return func(st *settings) error {
+		sorted, err := scale.New(s.Name, s.Sizes)
+		if err != nil {
+			return err
+		}
+		st.scale = sorted
+		return nil
+	}
+}
+
+// WithScalePreset estimates in the built-in scale with the given name, one of ScalePresets
+func WithScalePreset(name string) Option {
+	return func(st *settings) error {
+		s, err := scale.Preset(name)
+		if err != nil {
+			return err
+		}
+		st.scale = s
+		return nil
+	}
+}
+
+// WithScaleFile estimates in the scale defined by a YAML or JSON file
+func WithScaleFile(filename string) Option {
+	return func(st *settings) error {
+		s, err := scale.Load(filename)
+		if err != nil {
+			return err
+		}
+		st.scale = s
+		return nil
+	}
+}
+
+// WithMaxTasks limits the number of tasks in a combination and of items selected by a plan
+func WithMaxTasks(n int) Option {
+	return func(st *settings) error {
+		if n <= 0 {
+			return fmt.Errorf("max tasks must be positive, got %d", n)
+		}
+		st.maxTasks = n
+		return nil
+	}
+}
+
+// WithCombinationLimit makes combination searches fail with ErrTooManyCombinations once they
+// find more than n combinations; 0, the default, finds them all
+func WithCombinationLimit(n int) Option {
+	return func(st *settings) error {
+		if n < 0 {
+			return fmt.Errorf("combination limit must not be negative, got %d", n)
+		}
+		st.limit = n
+		return nil
+	}
+}
+
+// WithConstraints limits the tasks of each size in a combination, e.g. at most one L, at least
+// three XS and no M; the sizes must be on the scale
+func WithConstraints(c Constraints) Option {
+	return func(st *settings) error {
+		st.constraints = c
+		return nil
+	}
+}
+
+// WithOutput makes the Write methods write to w in format, one of the Format constants;
+// by default they write text to standard output
+func WithOutput(w io.Writer, format string) Option {
+	return func(st *settings) error {
+		st.w = w
+		st.format = format
+		return nil
+	}
+}
+
+// WithTemplate makes the Write methods execute a Go text/template instead of the output format
+func WithTemplate(text string) Option {
+	return func(st *settings) error {
+		st.template = text
+		return nil
+	}
+}
+
+// WithStyle sets the width, colors and symbols of the text format
+func WithStyle(style Style) Option {
+	return func(st *settings) error {
+		if style.Width < 0 {
+			return fmt.Errorf("width must not be negative, got %d", style.Width)
+		}
+		st.style = style
+		return nil
+	}
+}
+
+// WithAdvice sets the task counts at which written combinations are commented on
+func WithAdvice(advice Advice) Option {
+	return func(st *settings) error {
+		if advice.LowTaskCount >= advice.HighTaskCount {
+			return fmt.Errorf("low task count advice must be lower than high task count advice")
+		}
+		if advice.HeavyLargeCount < 1 || advice.ManySmallCount < 1 {
+			return fmt.Errorf("heavy large and many small count advice must be at least 1")
+		}
+		st.advice = advice
+		return nil
+	}
+}
+
+// New creates an Estimator; without options it uses the T-shirt scale, DefaultMaxTasks and
+// writes text to standard output
+func New(opts ...Option) (*Estimator, error) {
+	st := settings{
+		scale:    scale.Default(),
+		maxTasks: DefaultMaxTasks,
+		w:        os.Stdout,
+		format:   FormatText,
+		advice:   config.Default().Advice,
+	}
+
+	return newEstimator(st, opts)
+}
+
+// With returns a copy of the Estimator with opts applied on top of its own options
+func (e *Estimator) With(opts ...Option) (*Estimator, error) {
+	return newEstimator(e.settings, opts)
+}
+
+// newEstimator creates an Estimator from st with opts applied
+func newEstimator(st settings, opts []Option) (*Estimator, error) {
+	for _, opt := range opts {
+		if err := opt(&st); err != nil {
+			return nil, err
+		}
+	}
+
+	var renderer render.Renderer
+	var err error
+	if st.template != "" {
+		renderer, err = render.NewTemplate(st.template, st.w, st.scale, st.advice)
+	} else {
+		renderer, err = render.New(st.format, st.w, st.scale, st.advice, st.style)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	calc := calculator.NewCalculatorWithScale(st.scale)
+	constraints, err := calc.NormalizeConstraints(st.constraints)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Estimator{
+		settings:    st,
+		calculator:  calc,
+		renderer:    renderer,
+		constraints: constraints,
+	}, nil
+}
+
+// Scale returns the scale tasks are estimated in
+func (e *Estimator) Scale() Scale {
+	return e.calculator.Scale()
+}
+
+// MaxTasks returns the maximum number of tasks in a combination or plan
+func (e *Estimator) MaxTasks() int {
+	return e.settings.maxTasks
+}
+
+// Points returns the total points of the task counts
+func (e *Estimator) Points(ctx context.Context, tasks TaskCount) (int, error) {
+	if err := e.validate(ctx, tasks); err != nil {
+		return 0, err
+	}
+
+	return e.calculator.CalculatePoints(tasks), nil
+}
+
+// Capacity returns the points, task count, per-size breakdown and effort of the task counts
+func (e *Estimator) Capacity(ctx context.Context, tasks TaskCount) (SprintCapacity, error) {
+	if err := e.validate(ctx, tasks); err != nil {
+		return SprintCapacity{}, err
+	}
+
+	return e.calculator.CalculateSprintCapacity(tasks), nil
+}
+
+// BacklogCapacity returns the capacity of backlog items, listing the items of each size in the breakdown
+func (e *Estimator) BacklogCapacity(ctx context.Context, items []BacklogItem) (SprintCapacity, error) {
+	if err := ctx.Err(); err != nil {
+		return SprintCapacity{}, err
+	}
+
+	return e.calculator.CalculateBacklogCapacity(items)
+}
+
+// Combinations returns every combination of at most MaxTasks tasks adding up to points, fewest
+// tasks first, that respects the constraints of WithConstraints and the limit of WithCombinationLimit
+func (e *Estimator) Combinations(ctx context.Context, points int) (CombinationResult, error) {
+	if points <= 0 {
+		return CombinationResult{}, fmt.Errorf("points must be positive")
+	}
+
+	return e.calculator.FindCombinationsLimit(ctx, points, e.settings.maxTasks, e.constraints, e.settings.limit)
+}
+
+// EachCombination calls yield with every combination Combinations would return, as the search
+// finds them rather than fewest tasks first, stopping with the first error of yield
+func (e *Estimator) EachCombination(ctx context.Context, points int, yield func(Combination) error) error {
+	if points <= 0 {
+		return fmt.Errorf("points must be positive")
+	}
+
+	found := 0
+	return e.calculator.EachCombination(ctx, points, e.settings.maxTasks, e.constraints, func(combo Combination) error {
+		if limit := e.settings.limit; limit > 0 && found == limit {
+			return fmt.Errorf("%w: more than %d found", ErrTooManyCombinations, limit)
+		}
+		found++
+		return yield(combo)
+	})
+}
+
+// Plan selects at most MaxTasks backlog items, given in priority order, whose points best fit
+// points, together with their prerequisites
+func (e *Estimator) Plan(ctx context.Context, items []BacklogItem, points int) (PlanResult, error) {
+	return e.calculator.PlanBacklogContext(ctx, items, points, e.settings.maxTasks)
+}
+
+// TeamCapacity compares the points the team can deliver in the sprint with the planned points
+func (e *Estimator) TeamCapacity(ctx context.Context, team Team, plannedPoints int) (TeamCapacity, error) {
+	if err := ctx.Err(); err != nil {
+		return TeamCapacity{}, err
+	}
+
+	return e.calculator.CalculateTeamCapacity(team, plannedPoints)
+}
+
+// Velocity returns velocity statistics of the sprint history, with a rolling average over window sprints
+func (e *Estimator) Velocity(ctx context.Context, records []SprintRecord, window int) (VelocityReport, error) {
+	if err := ctx.Err(); err != nil {
+		return VelocityReport{}, err
+	}
+
+	return e.calculator.CalculateVelocity(records, window)
+}
+
+// ForecastSprint simulates trials sprints from the history to estimate the chance of completing plannedPoints
+func (e *Estimator) ForecastSprint(ctx context.Context, records []SprintRecord, plannedPoints, trials int, rng *rand.Rand) (SprintForecast, error) {
+	return e.calculator.ForecastSprintContext(ctx, records, plannedPoints, trials, rng)
+}
+
+// ForecastRelease simulates trials releases from the history to estimate the sprints, and their
+// end dates from start on, needed to complete the task counts
+func (e *Estimator) ForecastRelease(ctx context.Context, records []SprintRecord, tasks TaskCount, start time.Time, sprintLength, trials int, rng *rand.Rand) (ReleaseForecast, error) {
+	if err := e.validate(ctx, tasks); err != nil {
+		return ReleaseForecast{}, err
+	}
+
+	return e.calculator.ForecastReleaseContext(ctx, records, tasks, start, sprintLength, trials, rng)
+}
+
+// WriteCapacity writes a capacity report in the configured output
+func (e *Estimator) WriteCapacity(report CapacityReport) error {
+	return e.renderer.Capacity(report)
+}
+
+// WriteCombinations writes combinations in the configured output
+func (e *Estimator) WriteCombinations(result CombinationResult) error {
+	return e.renderer.Combinations(result)
+}
+
+// WritePlan writes a sprint plan in the configured output
+func (e *Estimator) WritePlan(result PlanResult) error {
+	return e.renderer.Plan(result)
+}
+
+// WriteVelocity writes a velocity report in the configured output
+func (e *Estimator) WriteVelocity(report VelocityReport) error {
+	return e.renderer.Velocity(report)
+}
+
+// WriteRelease writes a release forecast in the configured output
+func (e *Estimator) WriteRelease(forecast ReleaseForecast) error {
+	return e.renderer.Release(forecast)
+}
+
+// validate checks that ctx is not done and the task counts are valid on the scale
+func (e *Estimator) validate(ctx context.Context, tasks TaskCount) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return e.calculator.ValidateTasks(tasks)
+}
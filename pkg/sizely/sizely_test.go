@@ -0,0 +1,255 @@
+package sizely_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gr1m0h/sizely/pkg/sizely"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOptions(t *testing.T) {
+	scaleFile := filepath.Join(t.TempDir(), "scale.yaml")
+	require.NoError(t, os.WriteFile(scaleFile, []byte("name: team\nsizes:\n  - {name: S, points: 2}\n  - {name: XL, points: 13}\n"), 0o644))
+
+	custom, err := sizely.NewScale("custom", []sizely.Size{{Name: "B", Points: 8}, {Name: "A", Points: 1}})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		opts      []sizely.Option
+		wantScale []string
+		wantMax   int
+		wantErr   string
+	}{
+		{name: "Defaults", wantScale: []string{"XS", "S", "M", "L"}, wantMax: sizely.DefaultMaxTasks},
+		{name: "Preset", opts: []sizely.Option{sizely.WithScalePreset("linear"), sizely.WithMaxTasks(4)}, wantScale: []string{"XS", "S", "M", "L", "XL"}, wantMax: 4},
+		{name: "Scale file", opts: []sizely.Option{sizely.WithScaleFile(scaleFile)}, wantScale: []string{"S", "XL"}, wantMax: sizely.DefaultMaxTasks},
+		{name: "Scale", opts: []sizely.Option{sizely.WithScale(custom)}, wantScale: []string{"A", "B"}, wantMax: sizely.DefaultMaxTasks},
+		{name: "Unsorted scale", opts: []sizely.Option{sizely.WithScale(sizely.Scale{Name: "hand", Sizes: []sizely.Size{{Name: "B", Points: 8}, {Name: "A", Points: 1}}})}, wantScale: []string{"A", "B"}, wantMax: sizely.DefaultMaxTasks},
+		{name: "Unknown preset", opts: []sizely.Option{sizely.WithScalePreset("poker")}, wantErr: "unknown scale preset"},
+		{name: "Invalid scale", opts: []sizely.Option{sizely.WithScale(sizely.Scale{})}, wantErr: "at least one size"},
+		{name: "Zero max tasks", opts: []sizely.Option{sizely.WithMaxTasks(0)}, wantErr: "max tasks must be positive"},
+		{name: "Unknown format", opts: []sizely.Option{sizely.WithOutput(&bytes.Buffer{}, "xml")}, wantErr: "unknown output format"},
+		{name: "Invalid template", opts: []sizely.Option{sizely.WithTemplate("{{.TotalPoints")}, wantErr: "parsing template"},
+		{name: "Negative width", opts: []sizely.Option{sizely.WithStyle(sizely.Style{Width: -1})}, wantErr: "width must not be negative"},
+		{name: "Unknown constrained size", opts: []sizely.Option{sizely.WithConstraints(sizely.Constraints{Exclude: []string{"XXL"}})}, wantErr: `unknown size "XXL"`},
+		{name: "Constraints checked against the final scale", opts: []sizely.Option{sizely.WithConstraints(sizely.Constraints{Max: sizely.TaskCount{"XL": 1}}), sizely.WithScalePreset("linear")}, wantScale: []string{"XS", "S", "M", "L", "XL"}, wantMax: sizely.DefaultMaxTasks},
+		{name: "Invalid advice", opts: []sizely.Option{sizely.WithAdvice(sizely.Advice{LowTaskCount: 5, HighTaskCount: 5})}, wantErr: "must be lower"},
+		{name: "Zero advice threshold", opts: []sizely.Option{sizely.WithAdvice(sizely.Advice{LowTaskCount: 5, HighTaskCount: 10, ManySmallCount: 6})}, wantErr: "must be at least 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			est, err := sizely.New(tt.opts...)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantScale, est.Scale().Names())
+			assert.Equal(t, tt.wantMax, est.MaxTasks())
+		})
+	}
+}
+
+func TestUnsortedScale(t *testing.T) {
+	var buf bytes.Buffer
+	hand := sizely.Scale{Name: "hand", Sizes: []sizely.Size{{Name: "B", Points: 8}, {Name: "A", Points: 1}}}
+	est, err := sizely.New(sizely.WithScale(hand), sizely.WithOutput(&buf, sizely.FormatText), sizely.WithStyle(sizely.Style{ASCII: true}))
+	require.NoError(t, err)
+
+	result, err := est.Combinations(context.Background(), 9)
+	require.NoError(t, err)
+	require.NotEmpty(t, result.Combinations)
+	assert.Equal(t, sizely.TaskCount{"A": 1, "B": 1}, result.Combinations[0].Counts)
+
+	require.NoError(t, est.WriteCombinations(result))
+	assert.Contains(t, buf.String(), "Bx1 + Ax1", "labels list the largest size first")
+}
+
+func TestCalculations(t *testing.T) {
+	ctx := context.Background()
+	est, err := sizely.New(sizely.WithMaxTasks(3))
+	require.NoError(t, err)
+
+	points, err := est.Points(ctx, sizely.TaskCount{"xs": 3, "S": 2, "M": 1, "L": 1})
+	require.NoError(t, err)
+	assert.Equal(t, 24, points)
+
+	_, err = est.Capacity(ctx, sizely.TaskCount{"XXL": 1})
+	assert.ErrorContains(t, err, `unknown size "XXL"`)
+
+	capacity, err := est.BacklogCapacity(ctx, []sizely.BacklogItem{{ID: "A", Size: "M"}, {ID: "B", Size: "M"}})
+	require.NoError(t, err)
+	assert.Equal(t, 10, capacity.TotalPoints)
+
+	combinations, err := est.Combinations(ctx, 13)
+	require.NoError(t, err)
+	assert.Equal(t, 3, combinations.MaxTasks)
+	assert.NotEmpty(t, combinations.Combinations)
+	for _, combo := range combinations.Combinations {
+		assert.LessOrEqual(t, combo.TotalTasks(), 3)
+	}
+
+	_, err = est.Combinations(ctx, 0)
+	assert.ErrorContains(t, err, "points must be positive")
+
+	plan, err := est.Plan(ctx, []sizely.BacklogItem{{ID: "A", Size: "L"}, {ID: "B", Size: "M"}, {ID: "C", Size: "S"}}, 8)
+	require.NoError(t, err)
+	assert.Equal(t, 8, plan.SelectedPoints)
+
+	records := []sizely.SprintRecord{
+		{Sprint: "1", CompletedPoints: 20, Completed: sizely.TaskCount{"L": 2}},
+		{Sprint: "2", CompletedPoints: 30, Completed: sizely.TaskCount{"L": 3}},
+	}
+	velocity, err := est.Velocity(ctx, records, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 25.0, velocity.Average)
+
+	forecast, err := est.ForecastSprint(ctx, records, 20, 100, rand.New(rand.NewSource(1)))
+	require.NoError(t, err)
+	assert.Equal(t, 100, forecast.Trials)
+
+	release, err := est.ForecastRelease(ctx, records, sizely.TaskCount{"L": 5}, time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), 14, 100, rand.New(rand.NewSource(1)))
+	require.NoError(t, err)
+	assert.Equal(t, 50, release.TotalPoints)
+}
+
+func TestConstrainedCombinations(t *testing.T) {
+	est, err := sizely.New(sizely.WithConstraints(sizely.Constraints{
+		Min:     sizely.TaskCount{"XS": 3},
+		Max:     sizely.TaskCount{"L": 1},
+		Exclude: []string{"M"},
+	}))
+	require.NoError(t, err)
+
+	result, err := est.Combinations(context.Background(), 20)
+	require.NoError(t, err)
+	require.NotEmpty(t, result.Combinations)
+	for _, combo := range result.Combinations {
+		assert.GreaterOrEqual(t, combo.Counts["XS"], 3)
+		assert.LessOrEqual(t, combo.Counts["L"], 1)
+		assert.Zero(t, combo.Counts["M"])
+	}
+	assert.Equal(t, []string{"M"}, result.Constraints.Exclude)
+}
+
+func TestCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	est, err := sizely.New(sizely.WithMaxTasks(100))
+	require.NoError(t, err)
+
+	_, err = est.Points(ctx, sizely.TaskCount{"M": 1})
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = est.Combinations(ctx, 500)
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = est.Plan(ctx, nil, 10)
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = est.Velocity(ctx, nil, 3)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	records := []sizely.SprintRecord{{Sprint: "1", CompletedPoints: 10, Completed: sizely.TaskCount{"L": 1}}}
+	_, err = est.ForecastSprint(ctx, records, 10, 100, rand.New(rand.NewSource(1)))
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = est.ForecastRelease(ctx, records, sizely.TaskCount{"L": 2}, time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), 14, 100, rand.New(rand.NewSource(1)))
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestCombinationLimit(t *testing.T) {
+	ctx := context.Background()
+	est, err := sizely.New()
+	require.NoError(t, err)
+
+	all, err := est.Combinations(ctx, 13)
+	require.NoError(t, err)
+
+	limited, err := est.With(sizely.WithCombinationLimit(all.TotalFound))
+	require.NoError(t, err)
+	result, err := limited.Combinations(ctx, 13)
+	require.NoError(t, err)
+	assert.Equal(t, all, result)
+
+	limited, err = est.With(sizely.WithCombinationLimit(all.TotalFound-1), sizely.WithMaxTasks(4))
+	require.NoError(t, err)
+	assert.Equal(t, sizely.DefaultMaxTasks, est.MaxTasks())
+	assert.Equal(t, 4, limited.MaxTasks())
+
+	var streamed []sizely.Combination
+	err = est.EachCombination(ctx, 13, func(combo sizely.Combination) error {
+		streamed = append(streamed, combo)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, all.Combinations, streamed)
+
+	limited, err = est.With(sizely.WithCombinationLimit(2))
+	require.NoError(t, err)
+	_, err = limited.Combinations(ctx, 13)
+	assert.ErrorIs(t, err, sizely.ErrTooManyCombinations)
+
+	found := 0
+	err = limited.EachCombination(ctx, 13, func(sizely.Combination) error {
+		found++
+		return nil
+	})
+	assert.ErrorIs(t, err, sizely.ErrTooManyCombinations)
+	assert.Equal(t, 2, found)
+
+	_, err = est.With(sizely.WithCombinationLimit(-1))
+	assert.ErrorContains(t, err, "must not be negative")
+}
+
+func TestCombinationsStopOnDeadline(t *testing.T) {
+	// Searching ten sizes for up to 100 tasks visits trillions of partial combinations
+	sizes := make([]sizely.Size, 10)
+	for i := range sizes {
+		sizes[i] = sizely.Size{Name: fmt.Sprintf("S%d", i+1), Points: i + 1}
+	}
+	s, err := sizely.NewScale("wide", sizes)
+	require.NoError(t, err)
+	est, err := sizely.New(sizely.WithScale(s), sizely.WithMaxTasks(100))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = est.Combinations(ctx, 5000)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	est, err := sizely.New(sizely.WithOutput(&buf, sizely.FormatJSON))
+	require.NoError(t, err)
+
+	result, err := est.Combinations(context.Background(), 5)
+	require.NoError(t, err)
+	require.NoError(t, est.WriteCombinations(result))
+
+	var decoded sizely.CombinationResult
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, result, decoded)
+
+	buf.Reset()
+	est, err = sizely.New(sizely.WithOutput(&buf, sizely.FormatText), sizely.WithTemplate("{{.TotalPoints}} points"))
+	require.NoError(t, err)
+
+	capacity, err := est.Capacity(context.Background(), sizely.TaskCount{"L": 2})
+	require.NoError(t, err)
+	require.NoError(t, est.WriteCapacity(sizely.CapacityReport{Capacity: capacity}))
+	assert.Equal(t, "20 points", buf.String())
+}
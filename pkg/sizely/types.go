@@ -0,0 +1,123 @@
+package sizely
+
+import (
+	"github.com/gr1m0h/sizely/internal/calendar"
+	"github.com/gr1m0h/sizely/internal/config"
+	"github.com/gr1m0h/sizely/internal/models"
+	"github.com/gr1m0h/sizely/internal/render"
+	"github.com/gr1m0h/sizely/internal/scale"
+)
+
+// Task counts and backlogs
+type (
+	// TaskCount is the number of tasks for each size, keyed by size name
+	TaskCount = models.TaskCount
+	// BacklogItem is a single sized task, such as a ticket in the sprint backlog
+	BacklogItem = models.BacklogItem
+	// Constraints limits how many tasks of each size a combination may have
+	Constraints = models.Constraints
+)
+
+// Calculation results
+type (
+	// SprintCapacity is the points, task count and per-size breakdown of a set of tasks
+	SprintCapacity = models.SprintCapacity
+	// TaskBreakdown is the tasks and points of one size
+	TaskBreakdown = models.TaskBreakdown
+	// Effort is the PERT estimate, in hours, of the time needed for a set of tasks
+	Effort = models.Effort
+	// Combination is a combination of task counts adding up to a point target
+	Combination = models.Combination
+	// CombinationResult is every combination found for a point target
+	CombinationResult = models.CombinationResult
+	// PlannedItem is a backlog item considered by sprint planning
+	PlannedItem = models.PlannedItem
+	// PlanResult is the backlog items selected, and deferred, for a point target
+	PlanResult = models.PlanResult
+)
+
+// Teams
+type (
+	// Team is the people working on a sprint and how much they historically deliver
+	Team = models.Team
+	// Member is a team member and their availability during the sprint
+	Member = models.Member
+	// TeamCapacity is the team's deliverable points compared with the plan
+	TeamCapacity = models.TeamCapacity
+	// MemberCapacity is the points a single member can deliver in the sprint
+	MemberCapacity = models.MemberCapacity
+	// Commitment describes how planned points compare with team capacity
+	Commitment = models.Commitment
+	// Availability is the working days in a sprint for each member
+	Availability = calendar.Availability
+)
+
+// Commitments of a plan compared with the team's capacity
+const (
+	CommitmentUnder    = models.CommitmentUnder
+	CommitmentBalanced = models.CommitmentBalanced
+	CommitmentOver     = models.CommitmentOver
+)
+
+// Velocity and forecasts
+type (
+	// SprintRecord is the planned and completed work of a finished sprint
+	SprintRecord = models.SprintRecord
+	// VelocityReport is velocity statistics over the sprint history
+	VelocityReport = models.VelocityReport
+	// SizeVelocity is the average planned and completed tasks of one size per sprint
+	SizeVelocity = models.SizeVelocity
+	// SprintForecast is the simulated chance of completing the planned points in one sprint
+	SprintForecast = models.SprintForecast
+	// ForecastPercentile is the points reached in at least Confidence percent of simulated sprints
+	ForecastPercentile = models.ForecastPercentile
+	// ReleaseForecast is the simulated number of sprints and dates needed to burn down a backlog
+	ReleaseForecast = models.ReleaseForecast
+	// ReleasePercentile is the sprints needed in at least Confidence percent of simulations
+	ReleasePercentile = models.ReleasePercentile
+)
+
+// Scales
+type (
+	// Scale is the sizes tasks are estimated in, ordered by ascending points
+	Scale = scale.Scale
+	// Size is a named size, its points and optionally how long its tasks take
+	Size = scale.Size
+	// Hours is how long a task of one size takes, as a three-point estimate in hours
+	Hours = scale.Hours
+)
+
+// Output
+type (
+	// CapacityReport is everything written about a sprint's capacity
+	CapacityReport = render.CapacityReport
+	// Style controls how the text format draws on a terminal
+	Style = render.Style
+	// Advice holds the task counts at which combinations are commented on
+	Advice = config.Advice
+)
+
+// Output formats accepted by WithOutput
+const (
+	FormatText     = render.FormatText
+	FormatJSON     = render.FormatJSON
+	FormatYAML     = render.FormatYAML
+	FormatCSV      = render.FormatCSV
+	FormatMarkdown = render.FormatMarkdown
+	FormatHTML     = render.FormatHTML
+)
+
+// DefaultScale returns the classic T-shirt scale (XS=1, S=3, M=5, L=10)
+func DefaultScale() Scale {
+	return scale.Default()
+}
+
+// ScalePresets returns the names of the built-in scales
+func ScalePresets() []string {
+	return scale.PresetNames()
+}
+
+// NewScale creates a validated scale from sizes in any order
+func NewScale(name string, sizes []Size) (Scale, error) {
+	return scale.New(name, sizes)
+}